@@ -1,6 +1,9 @@
 package orm
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 type Action struct {
 	session *Session
@@ -23,9 +26,13 @@ func (o *Action) UpdatePayload(payload PayloadIfc) *Stmt {
 		schema:  o.schema,
 	}
 	stm.completeFn = stm.completeUpdate
+	stm.payloads = []PayloadIfc{payload}
 	bindFields := boundFields(payload)
 	for i := range bindFields {
 		item := bindFields[i]
+		if isComputed(item.field) {
+			continue
+		}
 		if item.Dirty() {
 			stm.sets = append(stm.sets, Cond{
 				left:  item.field,
@@ -62,13 +69,20 @@ func (o *Action) InsertPayload(rows ...PayloadIfc) *Stmt {
 	}
 	values := [][]*fieldBind{}
 	autoIncrementFields := []*fieldBind{}
+	autoIncrement := []FieldIfc{}
 	for i := range rows {
 		row := rows[i]
 		bindFields := boundFields(row)
 		notIgnoredFields := []*fieldBind{}
 		for j := range bindFields {
+			if isComputed(bindFields[j].field) {
+				continue
+			}
 			if bindFields[j].field.IsAutoIncrement() {
 				autoIncrementFields = append(autoIncrementFields, bindFields[j])
+				if i == 0 {
+					autoIncrement = append(autoIncrement, bindFields[j].field)
+				}
 				continue
 			}
 			notIgnoredFields = append(notIgnoredFields, bindFields[j])
@@ -80,12 +94,17 @@ func (o *Action) InsertPayload(rows ...PayloadIfc) *Stmt {
 		fields = append(fields, values[0][i].field)
 	}
 	stm := &Stmt{
-		session:     o.session,
-		schema:      o.schema,
-		selectField: fields,
-		values:      values,
+		session:       o.session,
+		schema:        o.schema,
+		selectField:   fields,
+		values:        values,
+		payloads:      rows,
+		autoIncrement: autoIncrement,
 	}
 	stm.completeFn = stm.completeInsert
+	if len(autoIncrementFields) == 0 {
+		return stm
+	}
 	stm.afterExecFn = func(row, id int64) {
 		for i := range autoIncrementFields {
 			f := autoIncrementFields[i]
@@ -94,3 +113,23 @@ func (o *Action) InsertPayload(rows ...PayloadIfc) *Stmt {
 	}
 	return stm
 }
+
+// InsertFrom inserts the rows selected by query into fields
+func (o *Action) InsertFrom(fields []FieldIfc, query *Stmt) *Stmt {
+	stm := &Stmt{
+		session:     o.session,
+		schema:      o.schema,
+		selectField: fields,
+		insertFrom:  query,
+	}
+	stm.completeFn = stm.completeInsert
+	switch {
+	case query == nil:
+		stm.err = errors.New("no query")
+	case query.err != nil:
+		stm.err = query.err
+	case len(fields) != len(query.columns()):
+		stm.err = fmt.Errorf("insert %d fields, but select %d fields", len(fields), len(query.columns()))
+	}
+	return stm
+}
@@ -0,0 +1,94 @@
+package orm
+
+import "fmt"
+
+var _ FieldIfc = (*Aggregate[any])(nil)
+
+// Aggregate is an aggregate function over a field, it works as a field in
+// select, order by and conditions
+type Aggregate[T any] struct {
+	typedOps[T]
+	fn       string
+	field    FieldIfc
+	distinct bool
+}
+
+func newAggregate[T any](fn string, field FieldIfc, distinct bool) *Aggregate[T] {
+	a := &Aggregate[T]{
+		fn:       fn,
+		field:    field,
+		distinct: distinct,
+	}
+	a.typedOps = typedOps[T]{self: a}
+	return a
+}
+
+// Count counts the rows where field is not null, COUNT(*) if field is nil
+func Count(field FieldIfc) *Aggregate[int64] {
+	return newAggregate[int64]("COUNT", field, false)
+}
+
+func CountDistinct(field FieldIfc) *Aggregate[int64] {
+	return newAggregate[int64]("COUNT", field, true)
+}
+
+func Sum[T any](field Field[T]) *Aggregate[T] {
+	return newAggregate[T]("SUM", &field, false)
+}
+
+func Avg[T any](field Field[T]) *Aggregate[float64] {
+	return newAggregate[float64]("AVG", &field, false)
+}
+
+func Min[T any](field Field[T]) *Aggregate[T] {
+	return newAggregate[T]("MIN", &field, false)
+}
+
+func Max[T any](field Field[T]) *Aggregate[T] {
+	return newAggregate[T]("MAX", &field, false)
+}
+
+func (a *Aggregate[T]) computed() {}
+
+func (a *Aggregate[T]) IsAutoIncrement() bool {
+	return false
+}
+
+func (a *Aggregate[T]) key() string {
+	arg := "*"
+	if a.field != nil {
+		arg = a.field.key()
+	}
+	if a.distinct {
+		arg = "DISTINCT " + arg
+	}
+	return fmt.Sprintf("%s(%s)", a.fn, arg)
+}
+
+func (a *Aggregate[T]) render(arg string) string {
+	if a.field == nil {
+		arg = "*"
+	}
+	if a.distinct {
+		arg = "DISTINCT " + arg
+	}
+	return fmt.Sprintf("%s(%s)", a.fn, arg)
+}
+
+func (a *Aggregate[T]) ColName(d Dialect) string {
+	if a.field == nil {
+		return a.render("")
+	}
+	return a.render(a.field.ColName(d))
+}
+
+func (a *Aggregate[T]) DBColName(d Dialect) string {
+	if a.field == nil {
+		return a.render("")
+	}
+	return a.render(a.field.DBColName(d))
+}
+
+func (a *Aggregate[T]) Expr(d Dialect) (string, []any) {
+	return a.DBColName(d), []any{}
+}
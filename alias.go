@@ -0,0 +1,234 @@
+package orm
+
+import (
+	"fmt"
+	"reflect"
+)
+
+var _ FieldIfc = (*ColumnAlias)(nil)
+var _ FieldIfc = (*exprField)(nil)
+
+// selectItem is a field rendered by an expression in the select list
+type selectItem interface {
+	selectExpr(d Dialect, withTableName bool) (string, []any)
+}
+
+// computedField is a field computed by the database rather than a column
+// of the table, it's not inserted or updated from a payload
+type computedField interface {
+	computed()
+}
+
+// selectFieldExpr renders a field of the select list
+func selectFieldExpr(d Dialect, field FieldIfc, withTableName bool) (string, []any) {
+	if item, ok := field.(selectItem); ok {
+		return item.selectExpr(d, withTableName)
+	}
+	if withTableName {
+		return field.DBColName(d), nil
+	}
+	return field.ColName(d), nil
+}
+
+// exprKey identifies an expression by its sql and args, the same sql bound
+// to other args is another field
+func exprKey(expr ExprIfc) string {
+	sql, args := expr.Expr(MySQL)
+	if len(args) == 0 {
+		return sql
+	}
+	return fmt.Sprintf("%s %#v", sql, args)
+}
+
+func isComputed(field FieldIfc) bool {
+	_, ok := field.(computedField)
+	return ok
+}
+
+// ColumnAlias is an expression selected as alias, it's referenced by the
+// alias in order by, having and payload binding
+type ColumnAlias struct {
+	typedOps[any]
+	expr  ExprIfc
+	alias string
+}
+
+// As selects expr as alias
+func As(expr ExprIfc, alias string) *ColumnAlias {
+	a := &ColumnAlias{
+		expr:  expr,
+		alias: alias,
+	}
+	a.typedOps = typedOps[any]{self: a}
+	return a
+}
+
+func (a *ColumnAlias) computed() {}
+
+func (a *ColumnAlias) selectExpr(d Dialect, withTableName bool) (expr string, args []any) {
+	if field, ok := a.expr.(FieldIfc); ok {
+		expr, args = selectFieldExpr(d, field, withTableName)
+	} else {
+		expr, args = a.expr.Expr(d)
+	}
+	expr = fmt.Sprintf("%s AS %s", expr, d.Quote(a.alias))
+	return
+}
+
+func (a *ColumnAlias) IsAutoIncrement() bool {
+	return false
+}
+
+func (a *ColumnAlias) key() string {
+	return a.alias
+}
+
+func (a *ColumnAlias) ColName(d Dialect) string {
+	if d == nil {
+		return a.alias
+	}
+	return d.Quote(a.alias)
+}
+
+func (a *ColumnAlias) DBColName(d Dialect) string {
+	return a.ColName(d)
+}
+
+func (a *ColumnAlias) Expr(d Dialect) (string, []any) {
+	return a.ColName(d), []any{}
+}
+
+// exprField is an arbitrary expression bound to a payload field
+type exprField struct {
+	expr ExprIfc
+}
+
+func (a *exprField) computed() {}
+
+func (a *exprField) selectExpr(d Dialect, withTableName bool) (string, []any) {
+	return a.expr.Expr(d)
+}
+
+func (a *exprField) IsAutoIncrement() bool {
+	return false
+}
+
+func (a *exprField) key() string {
+	return exprKey(a.expr)
+}
+
+func (a *exprField) ColName(d Dialect) string {
+	expr, _ := a.expr.Expr(d)
+	return expr
+}
+
+func (a *exprField) DBColName(d Dialect) string {
+	return a.ColName(d)
+}
+
+func (a *exprField) Expr(d Dialect) (string, []any) {
+	return a.expr.Expr(d)
+}
+
+// aliasSchema is a schema renamed by alias, the fields of it are referenced
+// by the alias
+type aliasSchema struct {
+	Schema
+	alias string
+}
+
+func (s *aliasSchema) TableName() string {
+	return s.alias
+}
+
+// schemaSetter is a field that can be moved to another schema
+type schemaSetter interface {
+	setSchema(s Schema)
+	getSchema() Schema
+}
+
+// Alias returns a copy of schema named alias, the fields of the copy
+// render as `alias`.`col` and the copy renders as `table` AS `alias` in
+// from and join, so the same table can be joined more than once. schema
+// must be a pointer to a struct of fields, the alias is known by them.
+func Alias[S Schema](schema S, alias string) S {
+	rv := reflect.ValueOf(schema)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		panic(fmt.Sprintf("orm: Alias needs pointer to struct, find: %T", schema))
+	}
+	as := &aliasSchema{Schema: schema, alias: alias}
+	cp := reflect.New(rv.Elem().Type())
+	cp.Elem().Set(rv.Elem())
+	for i := 0; i < cp.Elem().NumField(); i++ {
+		f := cp.Elem().Field(i)
+		if !f.CanSet() {
+			continue
+		}
+		if f.Kind() == reflect.Ptr && !f.IsNil() {
+			if _, ok := f.Interface().(schemaSetter); ok {
+				// copy the field, the original schema keeps its field
+				newF := reflect.New(f.Elem().Type())
+				newF.Elem().Set(f.Elem())
+				f.Set(newF)
+				f.Interface().(schemaSetter).setSchema(as)
+			}
+			continue
+		}
+		if setter, ok := f.Addr().Interface().(schemaSetter); ok {
+			setter.setSchema(as)
+		}
+	}
+	return cp.Interface().(S)
+}
+
+// aliasOf returns the aliasSchema of a schema returned by Alias, it's
+// known by the schema of the fields
+func aliasOf(schema Schema) (*aliasSchema, bool) {
+	if as, ok := schema.(*aliasSchema); ok {
+		return as, true
+	}
+	rv := reflect.ValueOf(schema)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return nil, false
+	}
+	for i := 0; i < rv.Elem().NumField(); i++ {
+		f := rv.Elem().Field(i)
+		if !f.CanSet() {
+			continue
+		}
+		if f.Kind() != reflect.Ptr {
+			f = f.Addr()
+		} else if f.IsNil() {
+			continue
+		}
+		if field, ok := f.Interface().(schemaSetter); ok {
+			as, ok := field.getSchema().(*aliasSchema)
+			return as, ok
+		}
+	}
+	return nil, false
+}
+
+// tableName returns the name referencing the table of schema, the alias if
+// the schema is aliased
+func tableName(schema Schema) string {
+	if as, ok := aliasOf(schema); ok {
+		return as.alias
+	}
+	return schema.TableName()
+}
+
+// tableExpr renders the table of schema in from and join
+func tableExpr(d Dialect, schema Schema) (string, []any) {
+	switch t := schema.(type) {
+	case *RawExpr:
+		return t.Expr(d)
+	case *DerivedTable:
+		return t.expr(d)
+	}
+	if as, ok := aliasOf(schema); ok {
+		table, args := tableExpr(d, as.Schema)
+		return fmt.Sprintf("%s AS %s", table, d.Quote(as.alias)), args
+	}
+	return d.Quote(schema.TableName()), nil
+}
@@ -0,0 +1,99 @@
+package orm
+
+import (
+	"strings"
+)
+
+var _ FieldIfc = (*CaseExpr)(nil)
+
+// CaseExpr is a CASE WHEN expression, it works as a field in select, order
+// by and the right side of update assignments
+type CaseExpr struct {
+	typedOps[any]
+	whens []caseWhen
+	els   ExprIfc
+}
+
+type caseWhen struct {
+	cond  Cond
+	value ExprIfc
+}
+
+// Case starts a CASE expression, add the branches by When. Without a
+// branch it renders the Else value, NULL if none
+func Case() *CaseExpr {
+	c := &CaseExpr{}
+	c.typedOps = typedOps[any]{self: c}
+	return c
+}
+
+// caseValue binds value as an argument unless it's an expression
+func caseValue(value any) ExprIfc {
+	if expr, ok := value.(ExprIfc); ok {
+		return expr
+	}
+	return anyVal{value}
+}
+
+// When results in value if cond matches, value is either an expression
+// such as a field or a value bound as argument
+func (c *CaseExpr) When(cond Cond, value any) *CaseExpr {
+	c.whens = append(c.whens, caseWhen{cond: cond, value: caseValue(value)})
+	return c
+}
+
+// Else results in value if no branch matches, NULL by default
+func (c *CaseExpr) Else(value any) *CaseExpr {
+	c.els = caseValue(value)
+	return c
+}
+
+func (c *CaseExpr) computed() {}
+
+func (c *CaseExpr) selectExpr(d Dialect, withTableName bool) (string, []any) {
+	return c.Expr(d)
+}
+
+func (c *CaseExpr) IsAutoIncrement() bool {
+	return false
+}
+
+func (c *CaseExpr) key() string {
+	return exprKey(c)
+}
+
+func (c *CaseExpr) ColName(d Dialect) string {
+	expr, _ := c.Expr(d)
+	return expr
+}
+
+func (c *CaseExpr) DBColName(d Dialect) string {
+	return c.ColName(d)
+}
+
+func (c *CaseExpr) Expr(d Dialect) (expr string, args []any) {
+	if len(c.whens) == 0 {
+		// CASE needs a branch, the result is the else value
+		if c.els == nil {
+			return "NULL", nil
+		}
+		return c.els.Expr(d)
+	}
+	sb := strings.Builder{}
+	sb.WriteString("CASE")
+	for i := range c.whens {
+		condE, condA := c.whens[i].cond.Expr(d)
+		valueE, valueA := c.whens[i].value.Expr(d)
+		sb.WriteString(" WHEN " + condE + " THEN " + valueE)
+		args = append(args, condA...)
+		args = append(args, valueA...)
+	}
+	if c.els != nil {
+		e, a := c.els.Expr(d)
+		sb.WriteString(" ELSE " + e)
+		args = append(args, a...)
+	}
+	sb.WriteString(" END")
+	expr = sb.String()
+	return
+}
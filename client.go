@@ -4,13 +4,30 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"sync/atomic"
 )
 
 type Client struct {
-	DB         *DefaultExecutor
+	DB *DefaultExecutor
+	// Dialect overrides the dialect chosen from the driver name
+	Dialect Dialect
+	// Strict fails the features not supported by the dialect, such as index
+	// hints, with ErrNotSupported instead of ignoring them
+	Strict     bool
 	driverName string
 }
 
+func (c *Client) session(db ExecutorIfc) *Session {
+	return &Session{db: db, dialect: c.dialect(), strict: c.Strict}
+}
+
+func (c *Client) dialect() Dialect {
+	if c.Dialect != nil {
+		return c.Dialect
+	}
+	return GetDialect(c.driverName)
+}
+
 func (c *Client) Transaction(ctx context.Context, fn func(s *Session) error) (err error) {
 	tx, err := c.DB.BeginTx(ctx, &sql.TxOptions{})
 	if err != nil {
@@ -26,16 +43,38 @@ func (c *Client) Transaction(ctx context.Context, fn func(s *Session) error) (er
 			err = tx.Commit()
 		}
 	}()
-	s := &Session{db: tx}
+	s := c.session(tx)
+	s.tx = true
 	return fn(s)
 }
 
 func (c *Client) Table(schema Schema) *Action {
-	s := &Session{db: c.DB}
+	s := c.session(c.DB)
 	return s.Table(schema)
 }
 
+// From selects from the derived table of query named alias, reference its
+// columns by FieldOf or NewField with NewTable(alias)
+func (c *Client) From(query *Stmt, alias string) *Action {
+	s := c.session(c.DB)
+	return s.From(query, alias)
+}
+
+// Raw runs the hand-written sql with args, write the placeholders as `?`
+func (c *Client) Raw(ctx context.Context, sql string, args ...any) *RawQuery {
+	s := c.session(c.DB)
+	return s.Raw(ctx, sql, args...)
+}
+
+// memoryDBs numbers the in-memory sqlite databases of the clients
+var memoryDBs atomic.Int64
+
 func NewClient(driverName, dataSourceName string) (*Client, error) {
+	if dataSourceName == ":memory:" && GetDialect(driverName) == SQLite {
+		// every connection opens its own :memory: database, the connections
+		// of the pool share a named one instead
+		dataSourceName = fmt.Sprintf("file:orm-memory-%d?mode=memory&cache=shared", memoryDBs.Add(1))
+	}
 	db, err := sql.Open(driverName, dataSourceName)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,125 @@
+package orm
+
+import (
+	"errors"
+	"strings"
+)
+
+var _ ExprIfc = (*compoundExpr)(nil)
+
+// compound combines the result sets of the selects
+type compound struct {
+	op    string
+	stmts []*Stmt
+}
+
+// Union combines the selects and removes the duplicate rows
+func Union(stmt ...*Stmt) *Stmt {
+	return newCompound("UNION", stmt)
+}
+
+// UnionAll combines the selects and keeps the duplicate rows
+func UnionAll(stmt ...*Stmt) *Stmt {
+	return newCompound("UNION ALL", stmt)
+}
+
+// Intersect returns the rows in every select
+func Intersect(stmt ...*Stmt) *Stmt {
+	return newCompound("INTERSECT", stmt)
+}
+
+// Except returns the rows of the first select not in the others
+func Except(stmt ...*Stmt) *Stmt {
+	return newCompound("EXCEPT", stmt)
+}
+
+// newCompound returns a select of the combined selects, only OrderBy, Limit
+// and Offset apply to the result, the selects without fields select the
+// fields of the payload
+func newCompound(op string, stmts []*Stmt) *Stmt {
+	if len(stmts) < 2 {
+		return &Stmt{err: errors.New("compound needs two selects at least")}
+	}
+	stm := &Stmt{
+		session:  stmts[0].session,
+		schema:   stmts[0].schema,
+		compound: &compound{op: op, stmts: stmts},
+	}
+	stm.completeFn = stm.completeCompound
+	for _, item := range stmts {
+		if item.err != nil {
+			stm.err = item.err
+		}
+	}
+	return stm
+}
+
+type compoundExpr struct {
+	op    string
+	stmts []*Stmt
+}
+
+func (e *compoundExpr) Expr(d Dialect) (expr string, args []any) {
+	selects := []string{}
+	for _, item := range e.stmts {
+		var sub ExprIfc = item.SubQuery()
+		if item.ordersOrLimits() {
+			sub = brackets{sub}
+		}
+		e, a := sub.Expr(d)
+		selects = append(selects, e)
+		args = append(args, a...)
+	}
+	expr = strings.Join(selects, " "+e.op+" ")
+	return
+}
+
+// ordersOrLimits reports whether the select of a compound is parenthesized
+func (a *Stmt) ordersOrLimits() bool {
+	return len(a.orderBy) > 0 || a.limit != nil || a.offset != nil
+}
+
+// hasCompoundParens reports whether a select of the compound is parenthesized
+func (a *Stmt) hasCompoundParens() bool {
+	if a.compound == nil {
+		return false
+	}
+	for _, item := range a.compound.stmts {
+		if item.ordersOrLimits() {
+			return true
+		}
+	}
+	return false
+}
+
+// compoundOrderBy orders the result of a compound by the column names
+type compoundOrderBy []Order
+
+func (a compoundOrderBy) Expr(d Dialect) (expr string, args []any) {
+	orders := []string{}
+	for _, o := range a {
+		e, oa := o.render(d, false)
+		orders = append(orders, e)
+		args = append(args, oa...)
+	}
+	expr = "order by " + strings.Join(orders, ", ")
+	return
+}
+
+func (a *Stmt) completeCompound() (ExprIfc, error) {
+	exprs := []ExprIfc{}
+	if len(a.ctes) > 0 {
+		exprs = append(exprs, withExpr(a.ctes))
+	}
+	exprs = append(exprs, &compoundExpr{
+		op:    a.compound.op,
+		stmts: a.compound.stmts,
+	})
+	if len(a.orderBy) > 0 {
+		exprs = append(exprs, compoundOrderBy(a.orderBy))
+	}
+	if a.limit != nil || a.offset != nil {
+		exprs = append(exprs, limitOffset{limit: a.limit, offset: a.offset})
+	}
+	return ExprSlice(exprs), a.err
+}
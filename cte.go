@@ -0,0 +1,51 @@
+package orm
+
+import (
+	"fmt"
+	"strings"
+)
+
+var _ ExprIfc = (*withExpr)(nil)
+
+// cte is a common table expression, referenced by a schema of the same table name
+type cte struct {
+	name      string
+	query     *Stmt
+	recursive bool
+}
+
+// With prepends the common table expression q named name
+func (a *Stmt) With(name string, q *Stmt) *Stmt {
+	a.ctes = append(a.ctes, cte{name: name, query: q})
+	return a
+}
+
+// WithRecursive prepends the recursive common table expression q named
+// name, q is usually a UnionAll of the initial select and the select
+// joined to name
+func (a *Stmt) WithRecursive(name string, q *Stmt) *Stmt {
+	a.ctes = append(a.ctes, cte{name: name, query: q, recursive: true})
+	return a
+}
+
+type withExpr []cte
+
+func (a withExpr) Expr(d Dialect) (expr string, args []any) {
+	if len(a) == 0 {
+		return
+	}
+	recursive := false
+	items := []string{}
+	for _, item := range a {
+		e, ar := item.query.SubQuery().Expr(d)
+		items = append(items, fmt.Sprintf("%s AS (%s)", d.Quote(item.name), e))
+		args = append(args, ar...)
+		recursive = recursive || item.recursive
+	}
+	expr = "WITH "
+	if recursive {
+		expr = "WITH RECURSIVE "
+	}
+	expr += strings.Join(items, ", ")
+	return
+}
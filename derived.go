@@ -0,0 +1,40 @@
+package orm
+
+var _ Schema = (*DerivedTable)(nil)
+
+// DerivedTable is the table of a query named by alias, such as a subquery
+// in join or from
+type DerivedTable struct {
+	query *Stmt
+	alias string
+}
+
+// Derived names the table of query alias
+func Derived(query *Stmt, alias string) *DerivedTable {
+	return &DerivedTable{
+		query: query,
+		alias: alias,
+	}
+}
+
+func (t *DerivedTable) TableName() string {
+	return t.alias
+}
+
+func (t *DerivedTable) IDField() FieldIfc {
+	return nil
+}
+
+func (t *DerivedTable) expr(d Dialect) (string, []any) {
+	e, args := brackets{t.query.SubQuery()}.Expr(d)
+	return e + " AS " + d.Quote(t.alias), args
+}
+
+// FieldOf references the column of field selected by the derived table
+// or common table expression of schema
+func FieldOf[T any](schema Schema, field Field[T]) Field[T] {
+	return Field[T]{
+		Name:   field.Name,
+		Schema: schema,
+	}
+}
@@ -0,0 +1,463 @@
+package orm
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Dialect renders the parts of a statement that differ between databases.
+// Expressions always emit `?` placeholders, the session rebinds them with
+// Placeholder before the statement is sent to the driver.
+type Dialect interface {
+	Name() string
+	// Quote escapes an identifier such as a table or column name
+	Quote(ident string) string
+	// Placeholder returns the n-th (1-based) bind parameter
+	Placeholder(n int) string
+	// LimitOffset renders the LIMIT/OFFSET clause, either may be nil
+	LimitOffset(limit, offset *int64) (string, []any)
+	// OnConflict renders the upsert clause following INSERT ... VALUES.
+	// target is the quoted conflict columns, key the quoted primary key
+	// column and sets the rendered assignments, do nothing if sets is empty
+	OnConflict(target []string, key string, sets []string) string
+	// Excluded references the value proposed for insertion of the quoted column
+	Excluded(col string) string
+	// SupportsReturning reports whether RETURNING can follow insert, update and delete.
+	// Auto increment ids are read back with RETURNING when supported, otherwise
+	// the driver's LastInsertId must report the first row of a multi-row insert
+	SupportsReturning() bool
+	// SupportsRowLock reports whether select can lock the rows by FOR UPDATE and FOR SHARE
+	SupportsRowLock() bool
+	// SupportsIndexHints reports whether the tables accept USE, FORCE and IGNORE INDEX
+	SupportsIndexHints() bool
+	// SupportsFullJoin reports whether FULL JOIN is supported
+	SupportsFullJoin() bool
+	// SupportsDistinctOn reports whether DISTINCT ON is supported
+	SupportsDistinctOn() bool
+	// SupportsUntargetedUpdate reports whether an upsert can update the
+	// existing row without naming the conflict target
+	SupportsUntargetedUpdate() bool
+	// SupportsCompoundParens reports whether the selects of a union can be
+	// parenthesized to order or limit them on their own
+	SupportsCompoundParens() bool
+	// MultiTableStyle reports how update and delete join other tables
+	MultiTableStyle() MultiTableStyle
+	// ILike renders the case-insensitive LIKE of the rendered operands
+	ILike(left, right string) string
+	// Regexp renders the regular expression match of the rendered operands
+	Regexp(left, right string) string
+	// JSONExtract renders the value as text at path such as $.a[0] of the json column
+	JSONExtract(col, path string) string
+	// JSONContains renders whether the json column contains the json document doc
+	JSONContains(col, doc string) string
+	// JSONSet renders the json column with the json value val set at path
+	JSONSet(col, path, val string) string
+}
+
+// MultiTableStyle is the syntax of update and delete joining other tables
+type MultiTableStyle int
+
+const (
+	// MultiTableJoin UPDATE t JOIN ... SET ... and DELETE t FROM t JOIN ...
+	MultiTableJoin MultiTableStyle = iota
+	// MultiTableFromUsing UPDATE t SET ... FROM ... and DELETE FROM t USING ...
+	// with the join conditions in WHERE, inner and cross joins only
+	MultiTableFromUsing
+	// MultiTableFrom UPDATE t SET ... FROM ... and DELETE FROM t WHERE EXISTS
+	// (SELECT 1 FROM ...), inner and cross joins only
+	MultiTableFrom
+)
+
+// builtin dialects
+var (
+	MySQL    Dialect = mysqlDialect{}
+	Postgres Dialect = postgresDialect{}
+	SQLite   Dialect = sqliteDialect{}
+)
+
+var (
+	dialectsMu sync.RWMutex
+	dialects   = map[string]Dialect{
+		"mysql":    MySQL,
+		"postgres": Postgres,
+		"pgx":      Postgres,
+		"sqlite":   SQLite,
+		"sqlite3":  SQLite,
+	}
+)
+
+// RegisterDialect makes a dialect available for the given driver name
+func RegisterDialect(driverName string, d Dialect) {
+	dialectsMu.Lock()
+	defer dialectsMu.Unlock()
+	if d == nil {
+		panic("orm: RegisterDialect dialect is nil")
+	}
+	dialects[driverName] = d
+}
+
+// GetDialect returns the dialect registered for driverName, MySQL if none
+func GetDialect(driverName string) Dialect {
+	dialectsMu.RLock()
+	defer dialectsMu.RUnlock()
+	if d, ok := dialects[driverName]; ok {
+		return d
+	}
+	return MySQL
+}
+
+type mysqlDialect struct{}
+
+func (mysqlDialect) Name() string {
+	return "mysql"
+}
+
+func (mysqlDialect) Quote(ident string) string {
+	return FieldWrapper(ident)
+}
+
+func (mysqlDialect) Placeholder(n int) string {
+	return "?"
+}
+
+func (mysqlDialect) LimitOffset(limit, offset *int64) (expr string, args []any) {
+	hasOffset := offset != nil && *offset != 0
+	switch {
+	case limit != nil:
+		expr = "LIMIT ?"
+		args = append(args, *limit)
+	case hasOffset:
+		// mysql can not use OFFSET without LIMIT
+		expr = "LIMIT 18446744073709551615"
+	}
+	if hasOffset {
+		expr += " OFFSET ?"
+		args = append(args, *offset)
+	}
+	return
+}
+
+// OnConflict mysql checks every unique key, the target is ignored
+func (mysqlDialect) OnConflict(target []string, key string, sets []string) string {
+	if len(sets) == 0 {
+		// do nothing by assigning the key to itself
+		sets = []string{key + " = " + key}
+	}
+	return "ON DUPLICATE KEY UPDATE " + strings.Join(sets, ", ")
+}
+
+func (mysqlDialect) Excluded(col string) string {
+	return "VALUES(" + col + ")"
+}
+
+func (mysqlDialect) SupportsReturning() bool {
+	return false
+}
+
+func (mysqlDialect) SupportsRowLock() bool {
+	return true
+}
+
+func (mysqlDialect) SupportsIndexHints() bool {
+	return true
+}
+
+func (mysqlDialect) SupportsFullJoin() bool {
+	return false
+}
+
+func (mysqlDialect) SupportsDistinctOn() bool {
+	return false
+}
+
+func (mysqlDialect) SupportsCompoundParens() bool {
+	return true
+}
+
+func (mysqlDialect) SupportsUntargetedUpdate() bool {
+	return true
+}
+
+func (mysqlDialect) MultiTableStyle() MultiTableStyle {
+	return MultiTableJoin
+}
+
+// ILike mysql has no ILIKE, the operands are lowered for binary collations
+func (mysqlDialect) ILike(left, right string) string {
+	return "LOWER(" + left + ") LIKE LOWER(" + right + ")"
+}
+
+func (mysqlDialect) Regexp(left, right string) string {
+	return left + " REGEXP " + right
+}
+
+func (mysqlDialect) JSONExtract(col, path string) string {
+	return col + "->>" + quoteString(path)
+}
+
+func (mysqlDialect) JSONContains(col, doc string) string {
+	return "JSON_CONTAINS(" + col + ", " + doc + ")"
+}
+
+func (mysqlDialect) JSONSet(col, path, val string) string {
+	return "JSON_SET(" + col + ", " + quoteString(path) + ", CAST(" + val + " AS JSON))"
+}
+
+type postgresDialect struct{}
+
+func (postgresDialect) Name() string {
+	return "postgres"
+}
+
+func (postgresDialect) Quote(ident string) string {
+	return quoteIdent(ident)
+}
+
+func (postgresDialect) Placeholder(n int) string {
+	return fmt.Sprintf("$%d", n)
+}
+
+func (postgresDialect) LimitOffset(limit, offset *int64) (expr string, args []any) {
+	exprs := []string{}
+	if limit != nil {
+		exprs = append(exprs, "LIMIT ?")
+		args = append(args, *limit)
+	}
+	if offset != nil && *offset != 0 {
+		exprs = append(exprs, "OFFSET ?")
+		args = append(args, *offset)
+	}
+	expr = strings.Join(exprs, " ")
+	return
+}
+
+func (postgresDialect) OnConflict(target []string, key string, sets []string) string {
+	return onConflict(target, sets)
+}
+
+func (postgresDialect) Excluded(col string) string {
+	return "EXCLUDED." + col
+}
+
+func (postgresDialect) SupportsReturning() bool {
+	return true
+}
+
+func (postgresDialect) SupportsRowLock() bool {
+	return true
+}
+
+func (postgresDialect) SupportsIndexHints() bool {
+	return false
+}
+
+func (postgresDialect) SupportsFullJoin() bool {
+	return true
+}
+
+func (postgresDialect) SupportsDistinctOn() bool {
+	return true
+}
+
+func (postgresDialect) SupportsCompoundParens() bool {
+	return true
+}
+
+func (postgresDialect) SupportsUntargetedUpdate() bool {
+	return false
+}
+
+func (postgresDialect) MultiTableStyle() MultiTableStyle {
+	return MultiTableFromUsing
+}
+
+func (postgresDialect) ILike(left, right string) string {
+	return left + " ILIKE " + right
+}
+
+func (postgresDialect) Regexp(left, right string) string {
+	return left + " ~ " + right
+}
+
+// JSONExtract postgres walks the path by -> and takes the text by ->>
+func (postgresDialect) JSONExtract(col, path string) string {
+	steps := jsonPathSteps(path)
+	expr := col
+	for i, step := range steps {
+		op := "->"
+		if i == len(steps)-1 {
+			op = "->>"
+		}
+		if step.index {
+			expr += op + step.name
+		} else {
+			expr += op + quoteString(step.name)
+		}
+	}
+	return expr
+}
+
+func (postgresDialect) JSONContains(col, doc string) string {
+	return col + " @> " + doc + "::jsonb"
+}
+
+func (postgresDialect) JSONSet(col, path, val string) string {
+	names := []string{}
+	for _, step := range jsonPathSteps(path) {
+		names = append(names, quoteIdent(step.name))
+	}
+	return "jsonb_set(" + col + ", " + quoteString("{"+strings.Join(names, ",")+"}") + ", " + val + "::jsonb)"
+}
+
+type sqliteDialect struct{}
+
+func (sqliteDialect) Name() string {
+	return "sqlite"
+}
+
+func (sqliteDialect) Quote(ident string) string {
+	return quoteIdent(ident)
+}
+
+func (sqliteDialect) Placeholder(n int) string {
+	return "?"
+}
+
+func (sqliteDialect) LimitOffset(limit, offset *int64) (expr string, args []any) {
+	hasOffset := offset != nil && *offset != 0
+	switch {
+	case limit != nil:
+		expr = "LIMIT ?"
+		args = append(args, *limit)
+	case hasOffset:
+		// sqlite can not use OFFSET without LIMIT
+		expr = "LIMIT -1"
+	}
+	if hasOffset {
+		expr += " OFFSET ?"
+		args = append(args, *offset)
+	}
+	return
+}
+
+func (sqliteDialect) OnConflict(target []string, key string, sets []string) string {
+	return onConflict(target, sets)
+}
+
+func (sqliteDialect) Excluded(col string) string {
+	return "excluded." + col
+}
+
+// SupportsReturning sqlite supports RETURNING since 3.35
+func (sqliteDialect) SupportsReturning() bool {
+	return true
+}
+
+// SupportsRowLock sqlite locks the whole database by the transaction
+func (sqliteDialect) SupportsRowLock() bool {
+	return false
+}
+
+func (sqliteDialect) SupportsIndexHints() bool {
+	return false
+}
+
+// SupportsFullJoin sqlite supports FULL JOIN since 3.39
+func (sqliteDialect) SupportsFullJoin() bool {
+	return true
+}
+
+func (sqliteDialect) SupportsDistinctOn() bool {
+	return false
+}
+
+func (sqliteDialect) SupportsCompoundParens() bool {
+	return false
+}
+
+func (sqliteDialect) SupportsUntargetedUpdate() bool {
+	return false
+}
+
+// MultiTableStyle sqlite supports UPDATE FROM since 3.33, but no DELETE USING
+func (sqliteDialect) MultiTableStyle() MultiTableStyle {
+	return MultiTableFrom
+}
+
+// ILike sqlite LIKE is case-insensitive for ascii
+func (sqliteDialect) ILike(left, right string) string {
+	return left + " LIKE " + right
+}
+
+// Regexp sqlite requires the regexp() function registered to the connection
+func (sqliteDialect) Regexp(left, right string) string {
+	return left + " REGEXP " + right
+}
+
+func (sqliteDialect) JSONExtract(col, path string) string {
+	return "json_extract(" + col + ", " + quoteString(path) + ")"
+}
+
+// JSONContains sqlite has no containment operator, it matches the top-level
+// elements of an array or the top-level members of an object of doc
+func (sqliteDialect) JSONContains(col, doc string) string {
+	// the elements of an array are keyed by the integer index
+	return "NOT EXISTS (SELECT 1 FROM json_each(" + doc + ") AS d WHERE NOT EXISTS " +
+		"(SELECT 1 FROM json_each(" + col + ") AS c WHERE c.type = d.type AND c.value = d.value " +
+		"AND (typeof(d.key) = 'integer' OR c.key = d.key)))"
+}
+
+func (sqliteDialect) JSONSet(col, path, val string) string {
+	return "json_set(" + col + ", " + quoteString(path) + ", json(" + val + "))"
+}
+
+// onConflict renders the standard ON CONFLICT clause shared by postgres and sqlite
+func onConflict(target []string, sets []string) string {
+	expr := "ON CONFLICT"
+	if len(target) > 0 {
+		expr += " (" + strings.Join(target, ", ") + ")"
+	}
+	if len(sets) == 0 {
+		return expr + " DO NOTHING"
+	}
+	return expr + " DO UPDATE SET " + strings.Join(sets, ", ")
+}
+
+// quoteString quotes a string literal with single quotes
+func quoteString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// quoteIdent quotes an identifier with the standard sql double quotes
+func quoteIdent(ident string) string {
+	return `"` + strings.ReplaceAll(ident, `"`, `""`) + `"`
+}
+
+// rebind replaces the `?` placeholders outside of quoted text with the
+// placeholders of the dialect
+func rebind(d Dialect, query string) string {
+	if d.Placeholder(1) == "?" {
+		return query
+	}
+	sb := strings.Builder{}
+	n := 0
+	var quote byte
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '?':
+			n++
+			sb.WriteString(d.Placeholder(n))
+			continue
+		}
+		sb.WriteByte(c)
+	}
+	return sb.String()
+}
@@ -0,0 +1,53 @@
+package e2etest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/archever/orm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAggregate(t *testing.T) {
+	ctx := context.Background()
+	cli := getClient(t)
+	payloads := []*teamStatPayload{}
+	err := cli.Table(user).Select().
+		GroupBy(user.TeamID).
+		OrderBy(orm.Count(nil).Desc(true)).
+		FindPayload(ctx, &payloads)
+	assert.NoError(t, err)
+	assert.Len(t, payloads, 2)
+	assert.EqualValues(t, 1, payloads[0].TeamID)
+	assert.EqualValues(t, 2, payloads[0].UserCnt)
+	assert.EqualValues(t, 3, payloads[0].MaxID)
+}
+
+func TestHaving(t *testing.T) {
+	ctx := context.Background()
+	cli := getClient(t)
+	payloads := []*teamStatPayload{}
+	err := cli.Table(user).Select().
+		GroupBy(user.TeamID).
+		Having(orm.Count(nil).Gt(1)).
+		FindPayload(ctx, &payloads)
+	assert.NoError(t, err)
+	assert.Len(t, payloads, 1)
+	assert.EqualValues(t, 1, payloads[0].TeamID)
+}
+
+func TestAggregateAlias(t *testing.T) {
+	ctx := context.Background()
+	cli := getClient(t)
+	cnt := orm.As(orm.Count(user.ID), "cnt")
+	payloads := []*teamUserCntPayload{}
+	err := cli.Table(user).Select().
+		Join(team, user.TeamID.EqCol(team.ID)).
+		GroupBy(team.Name).
+		OrderBy(cnt.Desc(true)).
+		FindPayload(ctx, &payloads)
+	assert.NoError(t, err)
+	assert.Len(t, payloads, 2)
+	assert.EqualValues(t, "team1", payloads[0].TeamName)
+	assert.EqualValues(t, 2, payloads[0].Cnt)
+}
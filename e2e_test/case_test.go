@@ -0,0 +1,63 @@
+package e2etest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/archever/orm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCaseOrder(t *testing.T) {
+	ctx := context.Background()
+	cli := getClient(t)
+
+	payloads := []*userPayload{}
+	priority := orm.Case().When(user.Name.Eq("name"), 0).Else(1)
+	err := cli.Table(user).Select().
+		OrderBy(priority.Asc(), user.ID.Desc(true)).
+		FindPayload(ctx, &payloads)
+	assert.NoError(t, err)
+	assert.Len(t, payloads, 3)
+	assert.EqualValues(t, "name", payloads[0].Name)
+	assert.EqualValues(t, "archever2", payloads[1].Name)
+
+	cnt, err := cli.Table(user).
+		Update(user.TeamID.SetExpr(orm.Case().When(user.TeamID.Eq(1), 2).Else(1))).
+		Do(ctx)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 3, cnt)
+
+	payloads = []*userPayload{}
+	err = cli.Table(user).Select().Where(user.TeamID.Eq(1)).FindPayload(ctx, &payloads)
+	assert.NoError(t, err)
+	assert.Len(t, payloads, 1)
+	assert.EqualValues(t, "archever2", payloads[0].Name)
+}
+
+var userSize = orm.Case().When(user.ID.Gt(1), "big").Else("small")
+
+type userSizePayload struct {
+	orm.PayloadBase
+	Size string
+	Cnt  int64
+}
+
+func (p *userSizePayload) Bind() {
+	orm.BindFieldIfc(&p.Size, userSize, &p.PayloadBase)
+	orm.BindFieldIfc(&p.Cnt, orm.Count(nil), &p.PayloadBase)
+}
+
+func TestCaseGroupBy(t *testing.T) {
+	ctx := context.Background()
+	cli := getClient(t)
+
+	rows := []*userSizePayload{}
+	err := cli.Table(user).Select().GroupBy(userSize).OrderBy(userSize.Asc()).FindPayload(ctx, &rows)
+	assert.NoError(t, err)
+	assert.Len(t, rows, 2)
+	assert.EqualValues(t, "big", rows[0].Size)
+	assert.EqualValues(t, 2, rows[0].Cnt)
+	assert.EqualValues(t, "small", rows[1].Size)
+	assert.EqualValues(t, 1, rows[1].Cnt)
+}
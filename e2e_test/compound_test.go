@@ -0,0 +1,53 @@
+package e2etest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/archever/orm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnion(t *testing.T) {
+	ctx := context.Background()
+	cli := getClient(t)
+	payloads := []*userPayload{}
+	err := orm.UnionAll(
+		cli.Table(user).Select().Where(user.TeamID.Eq(1)),
+		cli.Table(team).Select(),
+	).OrderBy(user.Name.Asc()).FindPayload(ctx, &payloads)
+	assert.NoError(t, err)
+	assert.Len(t, payloads, 4)
+	assert.EqualValues(t, "archever", payloads[0].Name)
+	assert.EqualValues(t, "team2", payloads[3].Name)
+}
+
+func TestIntersect(t *testing.T) {
+	ctx := context.Background()
+	cli := getClient(t)
+	payloads := []*teamPayload{}
+	err := orm.Intersect(
+		cli.Table(user).Select(user.TeamID),
+		cli.Table(team).Select(team.ID),
+	).OrderBy(team.ID.Asc()).FindPayload(ctx, &payloads)
+	assert.NoError(t, err)
+	assert.Len(t, payloads, 2)
+	assert.EqualValues(t, 1, payloads[0].ID)
+	assert.EqualValues(t, 2, payloads[1].ID)
+}
+
+func TestUnionOtherTable(t *testing.T) {
+	ctx := context.Background()
+	cli := getClient(t)
+	payloads := []*userPayload{}
+	err := orm.UnionAll(
+		cli.Table(team).Select(team.ID, team.Name),
+		cli.Table(user).Select(user.ID, user.Name),
+	).OrderBy(user.Name.Asc()).FindPayload(ctx, &payloads)
+	assert.NoError(t, err)
+	assert.Len(t, payloads, 5)
+	assert.EqualValues(t, 1, payloads[0].ID)
+	assert.EqualValues(t, "archever", payloads[0].Name)
+	assert.EqualValues(t, 2, payloads[4].ID)
+	assert.EqualValues(t, "team2", payloads[4].Name)
+}
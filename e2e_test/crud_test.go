@@ -2,95 +2,267 @@ package e2etest
 
 import (
 	"context"
-	"os"
 	"testing"
 
 	"github.com/archever/orm"
-	_ "github.com/go-sql-driver/mysql"
 	"github.com/stretchr/testify/assert"
 )
 
-func getClient() *orm.Client {
-	cli, err := orm.NewClient("mysql", os.Getenv("MYSQL_DSN"))
-	if err != nil {
-		panic(err)
-	}
-	return cli
-}
-
 func TestSelect(t *testing.T) {
 	ctx := context.Background()
-	cli := getClient()
+	cli := getClient(t)
 	var payload userPayload
-	err := cli.Table(user).Select().Where(user.ID.Eq(7)).TakePayload(ctx, &payload)
+	err := cli.Table(user).Select().Where(user.ID.Eq(1)).TakePayload(ctx, &payload)
 	assert.NoError(t, err)
-	t.Logf("%v", payload)
+	assert.EqualValues(t, 1, payload.ID)
+	assert.EqualValues(t, "archever", payload.Name)
 }
 
 func TestSelectMany(t *testing.T) {
 	ctx := context.Background()
-	cli := getClient()
+	cli := getClient(t)
 	payloads := []*userPayload{}
 	err := cli.Table(user).Select().Limit(10).FindPayload(ctx, &payloads)
 	assert.NoError(t, err)
-	t.Logf("%v", payloads[0])
-	t.Logf("%v", payloads[1])
+	assert.Len(t, payloads, 3)
+	assert.EqualValues(t, "archever", payloads[0].Name)
+	assert.EqualValues(t, "archever2", payloads[1].Name)
+}
+
+func TestSelectPage(t *testing.T) {
+	ctx := context.Background()
+	cli := getClient(t)
+	payloads := []*userPayload{}
+	err := cli.Table(user).Select().OrderBy(user.ID.Asc()).Offset(1).FindPayload(ctx, &payloads)
+	assert.NoError(t, err)
+	assert.Len(t, payloads, 2)
+	assert.EqualValues(t, 2, payloads[0].ID)
 }
 
 func TestInsertMany(t *testing.T) {
 	ctx := context.Background()
-	cli := getClient()
+	cli := getClient(t)
 	payload1 := userPayload{
-		Name: "archever1",
+		Name: "archever3",
 	}
 	payload2 := userPayload{
-		Name: "archever2",
+		Name: "archever4",
 	}
-	_, err := cli.Table(user).InsertPayload(&payload1, &payload2).Do(ctx)
+	cnt, err := cli.Table(user).InsertPayload(&payload1, &payload2).Do(ctx)
 	assert.NoError(t, err)
-	t.Logf("%v", payload1)
-	t.Logf("%v", payload2)
+	assert.EqualValues(t, 2, cnt)
+	assert.EqualValues(t, 4, payload1.ID)
+	assert.EqualValues(t, 5, payload2.ID)
 }
 
 func TestInsert(t *testing.T) {
 	ctx := context.Background()
-	cli := getClient()
+	cli := getClient(t)
 	payload := teamPayload{
-		Name: "team4",
+		Name: "team3",
 	}
-	_, err := cli.Table(user).InsertPayload(&payload).Do(ctx)
+	_, err := cli.Table(team).InsertPayload(&payload).Do(ctx)
 	assert.NoError(t, err)
-	t.Logf("%v", payload)
+	assert.EqualValues(t, 3, payload.ID)
 }
 
 func TestUpdatePayload(t *testing.T) {
 	ctx := context.Background()
-	cli := getClient()
+	cli := getClient(t)
 	var payload userPayload
-	err := cli.Table(user).Select().Where(user.ID.Eq(7)).TakePayload(ctx, &payload)
+	err := cli.Table(user).Select().Where(user.ID.Eq(1)).TakePayload(ctx, &payload)
 	assert.NoError(t, err)
-	t.Logf("%v", payload)
 
 	payload.Name = "archever1_1"
-	_, err = cli.Table(user).UpdatePayload(&payload).Where(user.ID.Eq(7)).Do(ctx)
+	cnt, err := cli.Table(user).UpdatePayload(&payload).Where(user.ID.Eq(1)).Do(ctx)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, cnt)
+
+	var updated userPayload
+	err = cli.Table(user).Select().Where(user.ID.Eq(1)).TakePayload(ctx, &updated)
 	assert.NoError(t, err)
-	t.Logf("%v", payload)
+	assert.EqualValues(t, "archever1_1", updated.Name)
 }
 
 func TestUpdate(t *testing.T) {
 	ctx := context.Background()
-	cli := getClient()
+	cli := getClient(t)
+
+	cnt, err := cli.Table(user).Update(user.Name.Eq("name2")).Where(user.ID.Eq(1)).Do(ctx)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, cnt)
+}
+
+func TestUpdateInTransaction(t *testing.T) {
+	ctx := context.Background()
+	cli := getClient(t)
+
+	err := cli.Transaction(ctx, func(s *orm.Session) error {
+		cnt, err := s.Table(user).Update(user.Name.Eq("name2")).Where(user.ID.Eq(1)).Do(ctx)
+		if err != nil {
+			return err
+		}
+		assert.EqualValues(t, 1, cnt)
+		// the client reads by another connection during the transaction
+		cnt, err = cli.Table(team).Select().Count(ctx)
+		assert.EqualValues(t, 2, cnt)
+		return err
+	})
+	assert.NoError(t, err)
+}
+
+func TestMemoryClients(t *testing.T) {
+	ctx := context.Background()
+	cli1 := getClient(t)
+	cli2 := getClient(t)
 
-	cnt, err := cli.Table(user).Update(user.Name.Eq("name2")).Where(user.ID.Eq(7)).Do(ctx)
+	payload := teamPayload{Name: "team3"}
+	_, err := cli1.Table(team).InsertPayload(&payload).Do(ctx)
 	assert.NoError(t, err)
-	t.Logf("%v", cnt)
+	cnt, err := cli1.Table(team).Select().Count(ctx)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 3, cnt)
+	// every client has its own database
+	cnt, err = cli2.Table(team).Select().Count(ctx)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, cnt)
+}
+
+func TestUpdateIncr(t *testing.T) {
+	ctx := context.Background()
+	cli := getClient(t)
+
+	cnt, err := cli.Table(user).
+		Update(user.TeamID.Incr(1), user.ManagerID.SetNull()).
+		Where(user.ID.Eq(2)).
+		Do(ctx)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, cnt)
+
+	var payload userPayload
+	err = cli.Table(user).Select().
+		Where(user.ID.Eq(2), user.TeamID.Eq(3), user.ManagerID.IsNull(true)).
+		TakePayload(ctx, &payload)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "archever2", payload.Name)
 }
 
 func TestDelete(t *testing.T) {
 	ctx := context.Background()
-	cli := getClient()
+	cli := getClient(t)
+
+	cnt, err := cli.Table(user).Delete().Where(user.ID.In(2, 3)).Do(ctx)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, cnt)
+}
+
+func TestUpsert(t *testing.T) {
+	ctx := context.Background()
+	cli := getClient(t)
+	payload := teamPayload{
+		Name: "team1",
+	}
+	_, err := cli.Table(team).InsertPayload(&payload).OnConflict(team.Name).DoNothing().Do(ctx)
+	assert.NoError(t, err)
+
+	payload = teamPayload{
+		Name: "team2",
+	}
+	_, err = cli.Table(team).InsertPayload(&payload).
+		OnConflict(team.Name).
+		DoUpdate(team.Name.Eq("team2_1")).
+		Do(ctx)
+	assert.NoError(t, err)
+
+	payloads := []*teamPayload{}
+	err = cli.Table(team).Select().OrderBy(team.ID.Asc()).FindPayload(ctx, &payloads)
+	assert.NoError(t, err)
+	assert.Len(t, payloads, 2)
+	assert.EqualValues(t, "team1", payloads[0].Name)
+	assert.EqualValues(t, "team2_1", payloads[1].Name)
+}
+
+func TestUpsertSkippedRow(t *testing.T) {
+	ctx := context.Background()
+	cli := getClient(t)
+	payload1 := teamPayload{Name: "team1"}
+	payload3 := teamPayload{Name: "team3"}
+	cnt, err := cli.Table(team).InsertPayload(&payload1, &payload3).OnConflict(team.Name).DoNothing().Do(ctx)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, cnt)
+	assert.EqualValues(t, 0, payload1.ID)
+
+	var inserted teamPayload
+	err = cli.Table(team).Select().Where(team.Name.Eq("team3")).TakePayload(ctx, &inserted)
+	assert.NoError(t, err)
+	assert.NotZero(t, inserted.ID)
+	assert.EqualValues(t, inserted.ID, payload3.ID)
+}
+
+func TestInsertFrom(t *testing.T) {
+	ctx := context.Background()
+	cli := getClient(t)
+	query := cli.Table(team).Select(team.Name, team.ID).Where(team.Name.Eq("team2"))
+	cnt, err := cli.Table(user).InsertFrom([]orm.FieldIfc{user.Name, user.TeamID}, query).Do(ctx)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, cnt)
+
+	var payload userPayload
+	err = cli.Table(user).Select().Where(user.TeamID.Eq(2), user.Name.Eq("team2")).TakePayload(ctx, &payload)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 4, payload.ID)
+}
+
+func TestInsertReturning(t *testing.T) {
+	ctx := context.Background()
+	cli := getClient(t)
+	payload1 := userPayload{
+		Name: "archever3",
+	}
+	payload2 := userPayload{
+		Name: "archever4",
+	}
+	cnt, err := cli.Table(user).InsertPayload(&payload1, &payload2).Returning().Do(ctx)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, cnt)
+	assert.EqualValues(t, 4, payload1.ID)
+	assert.EqualValues(t, 5, payload2.ID)
+}
+
+func TestDeleteReturning(t *testing.T) {
+	ctx := context.Background()
+	cli := getClient(t)
+	var payload userPayload
+	err := cli.Table(user).Delete().Where(user.ID.Eq(2)).Returning().TakePayload(ctx, &payload)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "archever2", payload.Name)
+}
+
+func TestJoinedUpdateDelete(t *testing.T) {
+	ctx := context.Background()
+	cli := getClient(t)
+
+	cnt, err := cli.Table(user).
+		Update(user.Name.SetCol(team.Name)).
+		Join(team, user.TeamID.EqCol(team.ID)).
+		Where(team.Name.Eq("team1")).
+		Do(ctx)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, cnt)
+
+	payloads := []*userPayload{}
+	err = cli.Table(user).Select().Where(user.Name.Eq("team1")).FindPayload(ctx, &payloads)
+	assert.NoError(t, err)
+	assert.Len(t, payloads, 2)
+
+	cnt, err = cli.Table(user).Delete().
+		Join(team, user.TeamID.EqCol(team.ID)).
+		Where(team.Name.Eq("team2")).
+		Do(ctx)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, cnt)
 
-	cnt, err := cli.Table(user).Delete().Where(user.ID.In(9, 10)).Do(ctx)
+	cnt, err = cli.Table(user).Select().Count(ctx)
 	assert.NoError(t, err)
-	t.Log(cnt)
+	assert.EqualValues(t, 2, cnt)
 }
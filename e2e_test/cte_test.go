@@ -0,0 +1,73 @@
+package e2etest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/archever/orm"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	reports       = orm.NewTable("reports")
+	reportsID     = orm.NewField[int64]("id", reports)
+	reportsName   = orm.NewField[string]("name", reports)
+	reportsTeamID = orm.NewField[int64]("team_id", reports)
+)
+
+type reportPayload struct {
+	orm.PayloadBase
+	ID       int64
+	Name     string
+	TeamName string
+}
+
+func (p *reportPayload) Bind() {
+	p.PayloadBase.BindField(&p.ID, reportsID)
+	p.PayloadBase.BindField(&p.Name, reportsName)
+	p.PayloadBase.BindField(&p.TeamName, team.Name)
+}
+
+func TestWithRecursive(t *testing.T) {
+	ctx := context.Background()
+	cli := getClient(t)
+	// every user reports to archever directly or indirectly
+	query := orm.UnionAll(
+		cli.Table(user).Select(user.ID, user.Name, user.TeamID).Where(user.ManagerID.Eq(1)),
+		cli.Table(user).Select(user.ID, user.Name, user.TeamID).Join(reports, user.ManagerID.EqCol(*reportsID)),
+	)
+	payloads := []*reportPayload{}
+	err := cli.Table(reports).Select().
+		WithRecursive("reports", query).
+		Join(team, reportsTeamID.EqCol(team.ID)).
+		OrderBy(reportsID.Asc()).
+		FindPayload(ctx, &payloads)
+	assert.NoError(t, err)
+	assert.Len(t, payloads, 2)
+	assert.EqualValues(t, "archever2", payloads[0].Name)
+	assert.EqualValues(t, "team2", payloads[0].TeamName)
+	assert.EqualValues(t, "name", payloads[1].Name)
+	assert.EqualValues(t, "team1", payloads[1].TeamName)
+}
+
+func TestWindowLatestPerGroup(t *testing.T) {
+	ctx := context.Background()
+	cli := getClient(t)
+	ranked := orm.NewTable("ranked")
+	rankedID := orm.NewField[int64]("id", ranked)
+	rankedRn := orm.NewField[int64]("rn", ranked)
+	latest := cli.Table(user).Select(
+		user.ID,
+		orm.As(orm.RowNumber().Over(orm.PartitionBy(user.TeamID).OrderBy(user.ID.Desc(true))), "rn"),
+	)
+	payloads := []*userPayload{}
+	err := cli.Table(user).Select().
+		With("ranked", latest).
+		Where(user.ID.InQuery(cli.Table(ranked).Select(rankedID).Where(rankedRn.Eq(1)).SubQuery())).
+		OrderBy(user.ID.Asc()).
+		FindPayload(ctx, &payloads)
+	assert.NoError(t, err)
+	assert.Len(t, payloads, 2)
+	assert.EqualValues(t, 2, payloads[0].ID)
+	assert.EqualValues(t, 3, payloads[1].ID)
+}
@@ -0,0 +1,50 @@
+package e2etest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/archever/orm"
+	"github.com/stretchr/testify/assert"
+)
+
+type userTeamPayload struct {
+	orm.PayloadBase
+	TeamID int64
+}
+
+func (p *userTeamPayload) Bind() {
+	p.PayloadBase.BindField(&p.TeamID, user.TeamID)
+}
+
+func TestDistinct(t *testing.T) {
+	ctx := context.Background()
+	cli := getClient(t)
+
+	payloads := []*userTeamPayload{}
+	err := cli.Table(user).Select().
+		Distinct().
+		OrderBy(user.TeamID.Asc()).
+		FindPayload(ctx, &payloads)
+	assert.NoError(t, err)
+	assert.Len(t, payloads, 2)
+
+	cnt, err := cli.Table(user).Select(user.TeamID).Distinct().Count(ctx)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, cnt)
+
+	cnt, err = cli.Table(user).Select(user.TeamID, user.ManagerID).Distinct().Count(ctx)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 3, cnt)
+
+	cnt, err = cli.Table(user).Select().Where(user.TeamID.Eq(1)).Limit(1).Count(ctx)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, cnt)
+
+	cnt, err = cli.Table(user).Select(team.ID, team.Name).
+		Join(team, user.TeamID.EqCol(team.ID)).
+		Distinct().
+		Count(ctx)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, cnt)
+}
@@ -0,0 +1,33 @@
+package e2etest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/archever/orm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExists(t *testing.T) {
+	ctx := context.Background()
+	cli := getClient(t)
+	_, err := cli.Table(team).InsertPayload(&teamPayload{Name: "team3"}).Do(ctx)
+	assert.NoError(t, err)
+
+	payloads := []*teamPayload{}
+	err = cli.Table(team).Select().
+		Where(orm.Exists(cli.Table(user).Select().Where(user.TeamID.EqCol(team.ID)))).
+		OrderBy(team.ID.Asc()).
+		FindPayload(ctx, &payloads)
+	assert.NoError(t, err)
+	assert.Len(t, payloads, 2)
+	assert.EqualValues(t, "team1", payloads[0].Name)
+
+	payloads = []*teamPayload{}
+	err = cli.Table(team).Select().
+		Where(orm.NotExists(cli.Table(user).Select().Where(user.TeamID.EqCol(team.ID)))).
+		FindPayload(ctx, &payloads)
+	assert.NoError(t, err)
+	assert.Len(t, payloads, 1)
+	assert.EqualValues(t, "team3", payloads[0].Name)
+}
@@ -0,0 +1,64 @@
+package e2etest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/archever/orm"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	rankedUser     = orm.NewTable("ranked")
+	rankedUserID   = orm.FieldOf(rankedUser, user.ID)
+	rankedUserName = orm.FieldOf(rankedUser, user.Name)
+	rankedUserRn   = orm.NewField[int64]("rn", rankedUser)
+)
+
+type rankedUserPayload struct {
+	orm.PayloadBase
+	ID   int64
+	Name string
+}
+
+func (p *rankedUserPayload) Bind() {
+	p.PayloadBase.BindField(&p.ID, rankedUserID)
+	p.PayloadBase.BindField(&p.Name, rankedUserName)
+}
+
+func TestFromTopN(t *testing.T) {
+	ctx := context.Background()
+	cli := getClient(t)
+	ranked := cli.Table(user).Select(
+		user.ID,
+		user.Name,
+		orm.As(orm.RowNumber().Over(orm.PartitionBy(user.TeamID).OrderBy(user.ID.Desc(true))), "rn"),
+	)
+	payloads := []*rankedUserPayload{}
+	err := cli.From(ranked, "ranked").Select().
+		Where(rankedUserRn.Eq(1)).
+		OrderBy(rankedUserID.Asc()).
+		FindPayload(ctx, &payloads)
+	assert.NoError(t, err)
+	assert.Len(t, payloads, 2)
+	assert.EqualValues(t, "archever2", payloads[0].Name)
+	assert.EqualValues(t, "name", payloads[1].Name)
+}
+
+func TestFromPage(t *testing.T) {
+	ctx := context.Background()
+	cli := getClient(t)
+	stat := cli.Table(user).
+		Select(user.TeamID, orm.As(orm.Count(nil), "cnt")).
+		GroupBy(user.TeamID)
+	payloads := []*teamStatCntPayload{}
+	err := cli.From(stat, "stat").Select().
+		Join(team, team.ID.EqCol(teamStatTeamID)).
+		OrderBy(teamStatCnt.Desc(true)).
+		Limit(1).Offset(1).
+		FindPayload(ctx, &payloads)
+	assert.NoError(t, err)
+	assert.Len(t, payloads, 1)
+	assert.EqualValues(t, "team2", payloads[0].Name)
+	assert.EqualValues(t, 1, payloads[0].Cnt)
+}
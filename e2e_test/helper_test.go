@@ -0,0 +1,30 @@
+package e2etest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/archever/orm"
+	_ "modernc.org/sqlite"
+)
+
+// getClient returns a client of a new in-memory sqlite database, initialized
+// by sql/sqlite.sql
+func getClient(t *testing.T) *orm.Client {
+	cli, err := orm.NewClient("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		cli.DB.Close()
+	})
+	schema, err := os.ReadFile(filepath.Join("sql", "sqlite.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cli.DB.Exec(string(schema)); err != nil {
+		t.Fatal(err)
+	}
+	return cli
+}
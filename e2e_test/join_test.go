@@ -4,42 +4,27 @@ import (
 	"context"
 	"testing"
 
-	_ "github.com/go-sql-driver/mysql"
+	"github.com/archever/orm"
 	"github.com/stretchr/testify/assert"
 )
 
-func Test_initData(t *testing.T) {
-	ctx := context.Background()
-	cli := getClient()
-	team1 := teamPayload{
-		Name: "team1",
-	}
-	team2 := teamPayload{
-		Name: "team2",
-	}
-	cnt, err := cli.Table(team).InsertPayload(&team1, &team2).Do(ctx)
-	assert.NoError(t, err)
-	t.Logf("team1: %v", team1)
-	t.Logf("team2: %v", team2)
-	t.Logf("cnt: %v", cnt)
-}
-
 func TestJoin(t *testing.T) {
 	ctx := context.Background()
-	cli := getClient()
+	cli := getClient(t)
 	var payload userPayload
 	// select user.* from user join team on user.team_id=team.id
 	err := cli.Table(user).Select().
 		Join(team, user.TeamID.EqCol(team.ID)).
+		Where(team.Name.Eq("team2")).
 		Limit(1).
 		TakePayload(ctx, &payload)
 	assert.NoError(t, err)
-	t.Logf("%v", payload)
+	assert.EqualValues(t, "archever2", payload.Name)
 }
 
 func TestJoinSelectAll(t *testing.T) {
 	ctx := context.Background()
-	cli := getClient()
+	cli := getClient(t)
 	var payload userAndTeamPayload
 	// select * from user join team on user.team_id=team.id
 	err := cli.Table(user).Select().
@@ -47,21 +32,108 @@ func TestJoinSelectAll(t *testing.T) {
 		Limit(1).
 		TakePayload(ctx, &payload)
 	assert.NoError(t, err)
-	t.Logf("%#v", payload)
+	assert.EqualValues(t, 1, payload.UserID)
+	assert.EqualValues(t, "archever", payload.Name)
+	assert.EqualValues(t, "team1", payload.TeamName)
 }
 
 func TestJoinSelectNest(t *testing.T) {
 	ctx := context.Background()
-	cli := getClient()
+	cli := getClient(t)
 	var payload userWithTeamPayload
 	// select * from user join team on user.team_id=team.id
 	err := cli.Table(user).Select().
 		Join(team, user.TeamID.EqCol(team.ID)).
 		Limit(1).
-		// TakePayload(ctx, &payload, &payload.TeamPtr, &payload.Team)
-		TakePayload(ctx, &payload)
+		TakePayload(ctx, &payload, &payload.TeamPtr)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "archever", payload.Name)
+	assert.EqualValues(t, "team1", payload.TeamPtr.Name)
+}
+
+var manager = orm.Alias(user, "manager")
+
+type userManagerPayload struct {
+	orm.PayloadBase
+	Name        string
+	ManagerName string
+}
+
+func (p *userManagerPayload) Bind() {
+	p.PayloadBase.BindField(&p.Name, user.Name)
+	p.PayloadBase.BindField(&p.ManagerName, manager.Name)
+}
+
+func TestJoinSelf(t *testing.T) {
+	ctx := context.Background()
+	cli := getClient(t)
+	payloads := []*userManagerPayload{}
+	err := cli.Table(user).Select().
+		Join(manager, user.ManagerID.EqCol(manager.ID)).
+		OrderBy(user.ID.Asc()).
+		FindPayload(ctx, &payloads)
+	assert.NoError(t, err)
+	assert.Len(t, payloads, 2)
+	assert.EqualValues(t, "archever2", payloads[0].Name)
+	assert.EqualValues(t, "archever", payloads[0].ManagerName)
+	assert.EqualValues(t, "name", payloads[1].Name)
+	assert.EqualValues(t, "archever2", payloads[1].ManagerName)
+}
+
+var (
+	teamStat       = orm.NewTable("stat")
+	teamStatTeamID = orm.FieldOf(teamStat, user.TeamID)
+	teamStatCnt    = orm.NewField[int64]("cnt", teamStat)
+)
+
+type teamStatCntPayload struct {
+	orm.PayloadBase
+	Name string
+	Cnt  int64
+}
+
+func (p *teamStatCntPayload) Bind() {
+	p.PayloadBase.BindField(&p.Name, team.Name)
+	p.PayloadBase.BindField(&p.Cnt, teamStatCnt)
+}
+
+func TestJoinSub(t *testing.T) {
+	ctx := context.Background()
+	cli := getClient(t)
+	stat := cli.Table(user).
+		Select(user.TeamID, orm.As(orm.Count(nil), "cnt")).
+		GroupBy(user.TeamID)
+	payloads := []*teamStatCntPayload{}
+	err := cli.Table(team).Select().
+		JoinSub(stat, "stat", team.ID.EqCol(teamStatTeamID)).
+		Where(teamStatCnt.Gt(1)).
+		FindPayload(ctx, &payloads)
+	assert.NoError(t, err)
+	assert.Len(t, payloads, 1)
+	assert.EqualValues(t, "team1", payloads[0].Name)
+	assert.EqualValues(t, 2, payloads[0].Cnt)
+}
+
+func TestJoinFull(t *testing.T) {
+	ctx := context.Background()
+	cli := getClient(t)
+	_, err := cli.Table(team).InsertPayload(&teamPayload{Name: "team3"}).Do(ctx)
+	assert.NoError(t, err)
+
+	payloads := []*teamPayload{}
+	err = cli.Table(team).Select().
+		FullJoin(user, user.TeamID.EqCol(team.ID)).
+		Where(user.ID.IsNull(true)).
+		FindPayload(ctx, &payloads)
+	assert.NoError(t, err)
+	assert.Len(t, payloads, 1)
+	assert.EqualValues(t, "team3", payloads[0].Name)
+
+	payloads = []*teamPayload{}
+	err = cli.Table(team).Select().
+		CrossJoin(setting).
+		Where(setting.ID.Eq(1)).
+		FindPayload(ctx, &payloads)
 	assert.NoError(t, err)
-	t.Logf("%#v", payload)
-	// t.Logf("%#v", payload.Team)
-	t.Logf("%#v", *payload.TeamPtr)
+	assert.Len(t, payloads, 3)
 }
@@ -0,0 +1,76 @@
+package e2etest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/archever/orm"
+	"github.com/stretchr/testify/assert"
+)
+
+var settingTheme = setting.Data.JSONPath("$.theme")
+
+type settingThemePayload struct {
+	orm.PayloadBase
+	ID    int64
+	Theme string
+}
+
+func (p *settingThemePayload) Bind() {
+	p.PayloadBase.BindField(&p.ID, setting.ID)
+	orm.BindFieldIfc(&p.Theme, settingTheme, &p.PayloadBase)
+}
+
+func TestJSON(t *testing.T) {
+	ctx := context.Background()
+	cli := getClient(t)
+	{
+		payloads := []*settingThemePayload{}
+		err := cli.Table(setting).Select().
+			Where(setting.Data.JSONPath("$.tags[0]").Eq("a")).
+			FindPayload(ctx, &payloads)
+		assert.NoError(t, err)
+		assert.Len(t, payloads, 1)
+		assert.EqualValues(t, "dark", payloads[0].Theme)
+	}
+	{
+		payloads := []*settingThemePayload{}
+		err := cli.Table(setting).Select().
+			Where(setting.Data.JSONPath("$.tags").IsNull(false)).
+			OrderBy(settingTheme.Desc(true)).
+			FindPayload(ctx, &payloads)
+		assert.NoError(t, err)
+		assert.Len(t, payloads, 2)
+		assert.EqualValues(t, "light", payloads[0].Theme)
+	}
+	{
+		payloads := []*settingPayload{}
+		err := cli.Table(setting).Select().
+			Where(setting.Data.JSONArrayContains("y")).
+			FindPayload(ctx, &payloads)
+		assert.NoError(t, err)
+		assert.Len(t, payloads, 1)
+		assert.EqualValues(t, 3, payloads[0].ID)
+	}
+	{
+		payloads := []*settingThemePayload{}
+		err := cli.Table(setting).Select().
+			Where(setting.Data.JSONContains(map[string]any{"theme": "light"})).
+			FindPayload(ctx, &payloads)
+		assert.NoError(t, err)
+		assert.Len(t, payloads, 1)
+		assert.EqualValues(t, 2, payloads[0].ID)
+	}
+	{
+		cnt, err := cli.Table(setting).
+			Update(setting.Data.JSONSet("$.theme", "blue")).
+			Where(setting.ID.Eq(1)).
+			Do(ctx)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 1, cnt)
+		var payload settingThemePayload
+		err = cli.Table(setting).Select().Where(setting.ID.Eq(1)).TakePayload(ctx, &payload)
+		assert.NoError(t, err)
+		assert.EqualValues(t, "blue", payload.Theme)
+	}
+}
@@ -0,0 +1,39 @@
+package e2etest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPattern(t *testing.T) {
+	ctx := context.Background()
+	cli := getClient(t)
+	{
+		payloads := []*userPayload{}
+		err := cli.Table(user).Select().
+			Where(user.Name.StartsWith("arch"), user.ID.Between(2, 3)).
+			FindPayload(ctx, &payloads)
+		assert.NoError(t, err)
+		assert.Len(t, payloads, 1)
+		assert.EqualValues(t, "archever2", payloads[0].Name)
+	}
+	{
+		payloads := []*userPayload{}
+		err := cli.Table(user).Select().
+			Where(user.Name.Contains("_")).
+			FindPayload(ctx, &payloads)
+		assert.NoError(t, err)
+		assert.Len(t, payloads, 0)
+	}
+	{
+		payloads := []*userPayload{}
+		err := cli.Table(user).Select().
+			Where(user.Name.ILike("ARCH%"), user.Name.NotLike("%2")).
+			FindPayload(ctx, &payloads)
+		assert.NoError(t, err)
+		assert.Len(t, payloads, 1)
+		assert.EqualValues(t, "archever", payloads[0].Name)
+	}
+}
@@ -50,3 +50,38 @@ func (p *userAndTeamPayload) Bind() {
 	p.PayloadBase.BindField(&p.Name, user.Name)
 	p.PayloadBase.BindField(&p.TeamName, team.Name)
 }
+
+type teamStatPayload struct {
+	orm.PayloadBase
+	TeamID  int64
+	UserCnt int64
+	MaxID   int64
+}
+
+func (p *teamStatPayload) Bind() {
+	p.PayloadBase.BindField(&p.TeamID, user.TeamID)
+	orm.BindFieldIfc(&p.UserCnt, orm.Count(nil), &p.PayloadBase)
+	orm.BindFieldIfc(&p.MaxID, orm.Max(user.ID), &p.PayloadBase)
+}
+
+type teamUserCntPayload struct {
+	orm.PayloadBase
+	TeamName string
+	Cnt      int64
+}
+
+func (p *teamUserCntPayload) Bind() {
+	orm.BindFieldIfc(&p.TeamName, orm.As(team.Name, "team_name"), &p.PayloadBase)
+	orm.BindFieldIfc(&p.Cnt, orm.As(orm.Count(user.ID), "cnt"), &p.PayloadBase)
+}
+
+type settingPayload struct {
+	orm.PayloadBase
+	ID   int64
+	Data string
+}
+
+func (p *settingPayload) Bind() {
+	p.PayloadBase.BindField(&p.ID, setting.ID)
+	p.PayloadBase.BindField(&p.Data, setting.Data)
+}
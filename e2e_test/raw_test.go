@@ -0,0 +1,62 @@
+package e2etest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/archever/orm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRawExpr(t *testing.T) {
+	ctx := context.Background()
+	cli := getClient(t)
+
+	payloads := []*userPayload{}
+	err := cli.Table(user).Select().
+		Where(orm.Raw(`length("user"."name") > ?`, 4).Cond()).
+		OrderBy(orm.Raw(`length("user"."name")`).Desc(true)).
+		FindPayload(ctx, &payloads)
+	assert.NoError(t, err)
+	assert.Len(t, payloads, 2)
+	assert.EqualValues(t, "archever2", payloads[0].Name)
+	assert.EqualValues(t, "archever", payloads[1].Name)
+}
+
+func TestRawQuery(t *testing.T) {
+	ctx := context.Background()
+	cli := getClient(t)
+
+	payloads := []*userPayload{}
+	err := cli.Raw(ctx, `SELECT name, id FROM "user" WHERE team_id = ? ORDER BY id`, 1).
+		FindPayload(&payloads)
+	assert.NoError(t, err)
+	assert.Len(t, payloads, 2)
+	assert.EqualValues(t, 1, payloads[0].ID)
+	assert.EqualValues(t, "archever", payloads[0].Name)
+
+	err = cli.Transaction(ctx, func(s *orm.Session) error {
+		ret, err := s.Raw(ctx, `UPDATE "user" SET name = ? WHERE id = ?`, "renamed", 3).Exec()
+		if err != nil {
+			return err
+		}
+		cnt, err := ret.RowsAffected()
+		assert.EqualValues(t, 1, cnt)
+		return err
+	})
+	assert.NoError(t, err)
+
+	var payload userPayload
+	var team teamPayload
+	err = cli.Raw(ctx, `SELECT u.id, u.name, t.id, t.name FROM "user" u JOIN team t ON u.team_id = t.id WHERE u.id = ?`, 3).
+		TakePayload(&payload, &team)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "renamed", payload.Name)
+	assert.EqualValues(t, 1, team.ID)
+	assert.EqualValues(t, "team1", team.Name)
+
+	var cnt int64
+	err = cli.Raw(ctx, `SELECT count(*) FROM "user"`).Scan(&cnt)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 3, cnt)
+}
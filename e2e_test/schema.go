@@ -5,15 +5,17 @@ import (
 )
 
 var user = &userSchema{
-	ID:     orm.Field[int64]{Name: "id", Schema: &userSchema{}, AutoIncrement: true},
-	Name:   orm.Field[string]{Name: "name", Schema: &userSchema{}},
-	TeamID: orm.Field[int64]{Name: "team_id", Schema: &userSchema{}},
+	ID:        orm.Field[int64]{Name: "id", Schema: &userSchema{}, AutoIncrement: true},
+	Name:      orm.Field[string]{Name: "name", Schema: &userSchema{}},
+	TeamID:    orm.Field[int64]{Name: "team_id", Schema: &userSchema{}},
+	ManagerID: orm.Field[int64]{Name: "manager_id", Schema: &userSchema{}},
 }
 
 type userSchema struct {
-	ID     orm.Field[int64]
-	Name   orm.Field[string]
-	TeamID orm.Field[int64]
+	ID        orm.Field[int64]
+	Name      orm.Field[string]
+	TeamID    orm.Field[int64]
+	ManagerID orm.Field[int64]
 }
 
 func (s *userSchema) TableName() string {
@@ -41,3 +43,21 @@ func (s *teamSchema) TableName() string {
 func (s *teamSchema) IDField() orm.FieldIfc {
 	return &s.ID
 }
+
+var setting = &settingSchema{
+	ID:   orm.Field[int64]{Name: "id", Schema: &settingSchema{}, AutoIncrement: true},
+	Data: orm.Field[string]{Name: "data", Schema: &settingSchema{}},
+}
+
+type settingSchema struct {
+	ID   orm.Field[int64]
+	Data orm.Field[string]
+}
+
+func (s *settingSchema) TableName() string {
+	return "setting"
+}
+
+func (s *settingSchema) IDField() orm.FieldIfc {
+	return &s.ID
+}
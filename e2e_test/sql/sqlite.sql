@@ -0,0 +1,22 @@
+CREATE TABLE "user" (
+    "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+    "name" TEXT NOT NULL DEFAULT '',
+    "team_id" INTEGER DEFAULT NULL,
+    "manager_id" INTEGER DEFAULT NULL
+);
+
+CREATE TABLE "team" (
+    "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+    "name" TEXT NOT NULL DEFAULT '' UNIQUE
+);
+
+CREATE TABLE "setting" (
+    "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+    "data" TEXT NOT NULL DEFAULT '{}'
+);
+
+INSERT INTO "team" ("name") VALUES ('team1'), ('team2');
+
+INSERT INTO "user" ("name", "team_id", "manager_id") VALUES ('archever', 1, NULL), ('archever2', 2, 1), ('name', 1, 2);
+
+INSERT INTO "setting" ("data") VALUES ('{"theme": "dark", "tags": ["a", "b"]}'), ('{"theme": "light", "tags": ["b"]}'), ('["x", "y"]');
@@ -4,13 +4,12 @@ import (
 	"context"
 	"testing"
 
-	_ "github.com/go-sql-driver/mysql"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestSubQuery(t *testing.T) {
 	ctx := context.Background()
-	cli := getClient()
+	cli := getClient(t)
 	var payload userPayload
 	subQuery := cli.Table(user).Select(&user.ID).Where(user.Name.Eq("name")).SubQuery()
 	err := cli.Table(user).Select().
@@ -18,7 +17,5 @@ func TestSubQuery(t *testing.T) {
 		Limit(1).
 		TakePayload(ctx, &payload)
 	assert.NoError(t, err)
-	t.Logf("%v", payload)
+	assert.EqualValues(t, 3, payload.ID)
 }
-
-// TODO: 支持 from subquery, columns in subquery
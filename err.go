@@ -6,5 +6,6 @@ import (
 
 // errors
 var (
-	ErrNotFund = errors.New("资源未找到")
+	ErrNotFund      = errors.New("资源未找到")
+	ErrNotSupported = errors.New("not supported by the dialect")
 )
@@ -6,26 +6,35 @@ import (
 )
 
 type ExprIfc interface {
-	Expr() (string, []any)
+	Expr(d Dialect) (string, []any)
 }
 
 var _ ExprIfc = (*Cond)(nil)
 var _ ExprIfc = (*groupExpr)(nil)
 var _ ExprIfc = (*Order)(nil)
 var _ ExprIfc = (*orderBy)(nil)
-var _ ExprIfc = (*limit)(nil)
-var _ ExprIfc = (*offset)(nil)
+var _ ExprIfc = (*limitOffset)(nil)
 var _ ExprIfc = (*groupBy)(nil)
 var _ ExprIfc = (*where)(nil)
+var _ ExprIfc = (*having)(nil)
 var _ ExprIfc = (*selectExpr)(nil)
 var _ ExprIfc = (*updateExpr)(nil)
+var _ ExprIfc = (*setExpr)(nil)
 var _ ExprIfc = (*deleteExpr)(nil)
 var _ ExprIfc = (*insertExpr)(nil)
+var _ ExprIfc = (*upsertExpr)(nil)
+var _ ExprIfc = (*returningExpr)(nil)
 var _ ExprIfc = (*ExprSlice)(nil)
 var _ ExprIfc = (*anyVal)(nil)
 var _ ExprIfc = (*anyValList)(nil)
 var _ ExprIfc = (*joinExpr)(nil)
 var _ ExprIfc = (*brackets)(nil)
+var _ ExprIfc = (*arithExpr)(nil)
+var _ ExprIfc = (*likePattern)(nil)
+var _ ExprIfc = (*betweenExpr)(nil)
+var _ ExprIfc = (*dialectOpExpr)(nil)
+var _ ExprIfc = (*nullExpr)(nil)
+var _ ExprIfc = (*existsExpr)(nil)
 var _ ExprIfc = (*fields)(nil)
 
 type Cond struct {
@@ -34,19 +43,19 @@ type Cond struct {
 	Op    string
 }
 
-func (c *Cond) Expr() (expr string, args []any) {
+func (c *Cond) Expr(d Dialect) (expr string, args []any) {
 	switch c.Op {
 	case "":
-		return c.left.Expr()
+		return c.left.Expr(d)
 	case "IS NULL", "IS NOT NULL":
-		leftE, leftA := c.left.Expr()
+		leftE, leftA := c.left.Expr(d)
 		expr = leftE + " " + c.Op
 		args = append(args, leftA...)
 	case "IN", "NOT IN":
 		fallthrough
 	default:
-		leftE, leftA := c.left.Expr()
-		rightE, rightA := c.right.Expr()
+		leftE, leftA := c.left.Expr(d)
+		rightE, rightA := c.right.Expr(d)
 		expr = fmt.Sprintf("%s %s %s", leftE, c.Op, rightE)
 		args = append(args, leftA...)
 		args = append(args, rightA...)
@@ -59,10 +68,10 @@ type groupExpr struct {
 	conds []Cond
 }
 
-func (a *groupExpr) Expr() (expr string, args []any) {
+func (a *groupExpr) Expr(d Dialect) (expr string, args []any) {
 	condExpr := []string{}
 	for _, cond := range a.conds {
-		e, a := cond.Expr()
+		e, a := cond.Expr(d)
 		condExpr = append(condExpr, e)
 		args = append(args, a...)
 	}
@@ -88,19 +97,53 @@ func Or(cond ...Cond) Cond {
 	}
 }
 
+// Exists matches if the query returns any row, correlate it to the outer
+// query by the conditions such as EqCol
+func Exists(query *Stmt) Cond {
+	return Cond{
+		left: &existsExpr{query: query},
+	}
+}
+
+// NotExists matches if the query returns no row
+func NotExists(query *Stmt) Cond {
+	return Cond{
+		left: &existsExpr{query: query, not: true},
+	}
+}
+
+type existsExpr struct {
+	query *Stmt
+	not   bool
+}
+
+func (a *existsExpr) Expr(d Dialect) (expr string, args []any) {
+	q := *a.query
+	if len(q.selectField) == 0 && q.compound == nil {
+		// the selected columns make no difference
+		q.selectField = []FieldIfc{Raw("1")}
+	}
+	e, args := brackets{q.SubQuery()}.Expr(d)
+	expr = "EXISTS " + e
+	if a.not {
+		expr = "NOT " + expr
+	}
+	return
+}
+
 func OrderBy(order ...Order) ExprIfc {
 	return orderBy(order)
 }
 
 type orderBy []Order
 
-func (a orderBy) Expr() (expr string, args []any) {
+func (a orderBy) Expr(d Dialect) (expr string, args []any) {
 	if len(a) == 0 {
 		return
 	}
 	orders := []string{}
 	for _, o := range a {
-		e, a := o.Expr()
+		e, a := o.Expr(d)
 		orders = append(orders, e)
 		args = append(args, a...)
 	}
@@ -113,48 +156,76 @@ type Order struct {
 	Desc  bool
 }
 
-func (a *Order) Expr() (expr string, args []any) {
-	expr = a.Field.DBColName(true)
+func (a *Order) Expr(d Dialect) (expr string, args []any) {
+	return a.render(d, true)
+}
+
+// render renders the order, the columns are qualified by the table name
+// only if withTableName
+func (a *Order) render(d Dialect, withTableName bool) (expr string, args []any) {
+	expr, args = fieldExpr(d, a.Field, withTableName)
 	if a.Desc {
 		expr += " DESC"
 	}
 	return
 }
 
+// fieldExpr renders a field referenced by order by or partition by, the
+// expressions with args such as case are rendered in full
+func fieldExpr(d Dialect, field FieldIfc, withTableName bool) (string, []any) {
+	expr, args := field.Expr(d)
+	if len(args) > 0 {
+		return expr, args
+	}
+	if withTableName {
+		return field.DBColName(d), nil
+	}
+	return field.ColName(d), nil
+}
+
 type limit int64
 type offset int64
 
-func (a *limit) Expr() (expr string, args []any) {
-	expr = "LIMIT ?"
-	args = []any{*a}
-	return
+type limitOffset struct {
+	limit  *limit
+	offset *offset
 }
 
-func (a *offset) Expr() (expr string, args []any) {
-	if *a == 0 {
-		return
-	}
-	expr = "OFFSET ?"
-	args = []any{*a}
-	return
+func (a limitOffset) Expr(d Dialect) (expr string, args []any) {
+	return d.LimitOffset((*int64)(a.limit), (*int64)(a.offset))
 }
 
 type selectExpr struct {
 	fields        []FieldIfc
 	schema        Schema
+	hints         []indexHint
+	distinct      bool
+	distinctOn    []FieldIfc
 	withTableName bool
 }
 
-func (a selectExpr) Expr() (expr string, args []any) {
+func (a selectExpr) Expr(d Dialect) (expr string, args []any) {
+	distinct := ""
+	if len(a.distinctOn) > 0 {
+		on := []string{}
+		for _, field := range a.distinctOn {
+			e, ar := fieldExpr(d, field, a.withTableName)
+			on = append(on, e)
+			args = append(args, ar...)
+		}
+		distinct = "DISTINCT ON (" + strings.Join(on, ", ") + ") "
+	} else if a.distinct {
+		distinct = "DISTINCT "
+	}
 	fields := []string{}
 	for _, field := range a.fields {
-		if a.withTableName {
-			fields = append(fields, field.DBColName(true))
-		} else {
-			fields = append(fields, field.ColName(true))
-		}
+		e, ar := selectFieldExpr(d, field, a.withTableName)
+		fields = append(fields, e)
+		args = append(args, ar...)
 	}
-	expr = fmt.Sprintf("SELECT %s FROM %s", strings.Join(fields, ", "), FieldWrapper(a.schema.TableName()))
+	table, tableA := tableExpr(d, a.schema)
+	expr = fmt.Sprintf("SELECT %s%s FROM %s%s", distinct, strings.Join(fields, ", "), table, indexHintsExpr(d, a.hints))
+	args = append(args, tableA...)
 	return
 }
 
@@ -163,14 +234,12 @@ type fields struct {
 	withTableName bool
 }
 
-func (a fields) Expr() (expr string, args []any) {
+func (a fields) Expr(d Dialect) (expr string, args []any) {
 	fields := []string{}
 	for _, field := range a.fields {
-		if a.withTableName {
-			fields = append(fields, field.DBColName(true))
-		} else {
-			fields = append(fields, field.ColName(true))
-		}
+		e, ar := selectFieldExpr(d, field, a.withTableName)
+		fields = append(fields, e)
+		args = append(args, ar...)
 	}
 	expr = strings.Join(fields, ", ")
 	return
@@ -186,25 +255,85 @@ func Select(table Schema, fields ...FieldIfc) ExprIfc {
 type updateExpr struct {
 	sets   []Cond
 	schema Schema
-}
-
-func (e *updateExpr) Expr() (expr string, args []any) {
+	joins  []joinExpr
+	conds  []Cond
+}
+
+func (e *updateExpr) Expr(d Dialect) (expr string, args []any) {
+	table, args := tableExpr(d, e.schema)
+	conds := e.conds
+	exprs := []string{"UPDATE " + table}
+	if len(e.joins) > 0 && d.MultiTableStyle() == MultiTableJoin {
+		for i := range e.joins {
+			j, a := e.joins[i].Expr(d)
+			exprs = append(exprs, j)
+			args = append(args, a...)
+		}
+	}
 	set := []string{}
 	for _, field := range e.sets {
-		s, a := field.Expr()
+		s, a := setExpr(field).Expr(d)
 		set = append(set, s)
 		args = append(args, a...)
 	}
-	expr = fmt.Sprintf("UPDATE %s SET %s", FieldWrapper(e.schema.TableName()), strings.Join(set, ", "))
+	exprs = append(exprs, "SET "+strings.Join(set, ", "))
+	if len(e.joins) > 0 && d.MultiTableStyle() != MultiTableJoin {
+		from, fromA, on := joinedTables(d, e.joins)
+		exprs = append(exprs, "FROM "+from)
+		args = append(args, fromA...)
+		conds = append(on, conds...)
+	}
+	if len(conds) > 0 {
+		w, a := Where(conds...).Expr(d)
+		exprs = append(exprs, w)
+		args = append(args, a...)
+	}
+	expr = strings.Join(exprs, " ")
 	return
 }
 
-type groupBy []FieldIfc
+// joinedTables renders the tables of the inner and cross joins as a list,
+// the join conditions are returned to filter in WHERE
+func joinedTables(d Dialect, joins []joinExpr) (expr string, args []any, conds []Cond) {
+	tables := []string{}
+	for _, join := range joins {
+		table, a := tableExpr(d, join.schema)
+		tables = append(tables, table)
+		args = append(args, a...)
+		conds = append(conds, join.on...)
+	}
+	expr = strings.Join(tables, ", ")
+	return
+}
+
+// setExpr is an assignment of UPDATE SET, only mysql accepts the column
+// qualified by the table name on the left side
+type setExpr Cond
 
-func (gb groupBy) Expr() (expr string, args []any) {
+func (a setExpr) Expr(d Dialect) (expr string, args []any) {
+	cond := Cond(a)
+	field, ok := a.left.(FieldIfc)
+	// the target is qualified by the table only where update joins tables
+	if !ok || d.MultiTableStyle() == MultiTableJoin || a.right == nil {
+		return cond.Expr(d)
+	}
+	rightE, rightA := a.right.Expr(d)
+	expr = fmt.Sprintf("%s %s %s", field.ColName(d), a.Op, rightE)
+	args = rightA
+	return
+}
+
+type groupBy struct {
+	fields        []FieldIfc
+	withTableName bool
+}
+
+func (gb groupBy) Expr(d Dialect) (expr string, args []any) {
 	fields := []string{}
-	for _, field := range gb {
-		fields = append(fields, field.ColName(true))
+	for _, field := range gb.fields {
+		e, a := fieldExpr(d, field, gb.withTableName)
+		fields = append(fields, e)
+		args = append(args, a...)
 	}
 	expr = "GROUP BY " + strings.Join(fields, ", ")
 	return
@@ -216,30 +345,50 @@ func Where(cond ...Cond) ExprIfc {
 	return where(cond)
 }
 
-func (a where) Expr() (expr string, args []any) {
+func (a where) Expr(d Dialect) (expr string, args []any) {
 	if len(a) == 0 {
 		return
 	}
-	var cond ExprIfc = &a[0]
-	if len(a) > 1 {
-		cond = &groupExpr{
-			op:    "AND",
-			conds: a,
-		}
-	}
-	e, ar := cond.Expr()
+	e, ar := andConds(a).Expr(d)
 	expr = "WHERE " + e
 	args = ar
 	return
 }
 
+type having []Cond
+
+func Having(cond ...Cond) ExprIfc {
+	return having(cond)
+}
+
+func (a having) Expr(d Dialect) (expr string, args []any) {
+	if len(a) == 0 {
+		return
+	}
+	e, ar := andConds(a).Expr(d)
+	expr = "HAVING " + e
+	args = ar
+	return
+}
+
+// andConds joins the conds by AND
+func andConds(conds []Cond) ExprIfc {
+	if len(conds) == 1 {
+		return &conds[0]
+	}
+	return &groupExpr{
+		op:    "AND",
+		conds: conds,
+	}
+}
+
 type ExprSlice []ExprIfc
 
-func (a ExprSlice) Expr() (expr string, args []any) {
+func (a ExprSlice) Expr(d Dialect) (expr string, args []any) {
 	sb := strings.Builder{}
 	length := len(a)
 	for i, e := range a {
-		e, a := e.Expr()
+		e, a := e.Expr(d)
 		if e != "" {
 			sb.WriteString(e)
 			if i < length-1 {
@@ -253,10 +402,48 @@ func (a ExprSlice) Expr() (expr string, args []any) {
 
 type deleteExpr struct {
 	schema Schema
-}
-
-func (e *deleteExpr) Expr() (expr string, args []any) {
-	expr = fmt.Sprintf("DELETE FROM %s", FieldWrapper(e.schema.TableName()))
+	joins  []joinExpr
+	conds  []Cond
+}
+
+func (e *deleteExpr) Expr(d Dialect) (expr string, args []any) {
+	table, args := tableExpr(d, e.schema)
+	conds := e.conds
+	exprs := []string{}
+	switch {
+	case len(e.joins) == 0:
+		exprs = append(exprs, "DELETE FROM "+table)
+	case d.MultiTableStyle() == MultiTableJoin:
+		exprs = append(exprs, fmt.Sprintf("DELETE %s FROM %s", d.Quote(tableName(e.schema)), table))
+		for i := range e.joins {
+			j, a := e.joins[i].Expr(d)
+			exprs = append(exprs, j)
+			args = append(args, a...)
+		}
+	case d.MultiTableStyle() == MultiTableFromUsing:
+		using, usingA, on := joinedTables(d, e.joins)
+		exprs = append(exprs, "DELETE FROM "+table, "USING "+using)
+		args = append(args, usingA...)
+		conds = append(on, conds...)
+	default:
+		// the conditions are correlated to the deleted table in EXISTS
+		from, fromA, on := joinedTables(d, e.joins)
+		sub := "SELECT 1 FROM " + from
+		w, a := Where(append(on, conds...)...).Expr(d)
+		if w != "" {
+			sub += " " + w
+		}
+		exprs = append(exprs, "DELETE FROM "+table, "WHERE EXISTS ("+sub+")")
+		args = append(args, fromA...)
+		args = append(args, a...)
+		conds = nil
+	}
+	if len(conds) > 0 {
+		w, a := Where(conds...).Expr(d)
+		exprs = append(exprs, w)
+		args = append(args, a...)
+	}
+	expr = strings.Join(exprs, " ")
 	return
 }
 
@@ -264,15 +451,23 @@ type insertExpr struct {
 	vales  [][]*fieldBind
 	fields []FieldIfc
 	schema Schema
+	// query is the select of INSERT ... SELECT, replaces the values
+	query ExprIfc
 }
 
-func (e *insertExpr) Expr() (expr string, args []any) {
+func (e *insertExpr) Expr(d Dialect) (expr string, args []any) {
 	fields := []string{}
 	for _, field := range e.fields {
-		fields = append(fields, field.ColName(true))
+		fields = append(fields, field.ColName(d))
+	}
+	if e.query != nil {
+		queryE, queryA := e.query.Expr(d)
+		expr = fmt.Sprintf("INSERT INTO %s (%s) %s", d.Quote(e.schema.TableName()), strings.Join(fields, ","), queryE)
+		args = queryA
+		return
 	}
 	sb := strings.Builder{}
-	sb.WriteString(fmt.Sprintf("INSERT INTO %s (%s) VALUES", FieldWrapper(e.schema.TableName()), strings.Join(fields, ",")))
+	sb.WriteString(fmt.Sprintf("INSERT INTO %s (%s) VALUES", d.Quote(e.schema.TableName()), strings.Join(fields, ",")))
 	rows := []string{}
 	for _, row := range e.vales {
 		values := []string{}
@@ -287,23 +482,81 @@ func (e *insertExpr) Expr() (expr string, args []any) {
 	return
 }
 
+type upsertExpr struct {
+	target  []FieldIfc
+	key     FieldIfc
+	updates []FieldIfc
+	sets    []Cond
+}
+
+func (e *upsertExpr) Expr(d Dialect) (expr string, args []any) {
+	target := []string{}
+	for _, field := range e.target {
+		target = append(target, field.ColName(d))
+	}
+	sets := []string{}
+	for _, field := range e.updates {
+		col := field.ColName(d)
+		sets = append(sets, col+" = "+d.Excluded(col))
+	}
+	for _, cond := range e.sets {
+		s, a := setExpr(cond).Expr(d)
+		sets = append(sets, s)
+		args = append(args, a...)
+	}
+	key := ""
+	if e.key != nil {
+		key = e.key.ColName(d)
+	}
+	expr = d.OnConflict(target, key, sets)
+	return
+}
+
+type returningExpr struct {
+	fields        []FieldIfc
+	withTableName bool
+}
+
+func (e *returningExpr) Expr(d Dialect) (expr string, args []any) {
+	fields := []string{}
+	for _, field := range e.fields {
+		f, a := selectFieldExpr(d, field, e.withTableName)
+		fields = append(fields, f)
+		args = append(args, a...)
+	}
+	expr = "RETURNING " + strings.Join(fields, ", ")
+	return
+}
+
 type joinExpr struct {
 	tp     string
 	on     []Cond
+	using  []FieldIfc
 	schema Schema
+	hints  []indexHint
 }
 
-func (a *joinExpr) Expr() (expr string, args []any) {
+func (a *joinExpr) Expr(d Dialect) (expr string, args []any) {
 	joinStr := "JOIN"
 	if a.tp != "" {
 		joinStr = fmt.Sprintf("%s %s", a.tp, joinStr)
 	}
-	expr = fmt.Sprintf("%s %s ON", joinStr, FieldWrapper(a.schema.TableName()))
-	for _, cond := range a.on {
-		e, a := cond.Expr()
-		expr += " " + e
-		args = append(args, a...)
+	table, args := tableExpr(d, a.schema)
+	expr = fmt.Sprintf("%s %s%s", joinStr, table, indexHintsExpr(d, a.hints))
+	if len(a.using) > 0 {
+		cols := []string{}
+		for _, field := range a.using {
+			cols = append(cols, field.ColName(d))
+		}
+		expr += " USING (" + strings.Join(cols, ", ") + ")"
+		return
 	}
+	if len(a.on) == 0 {
+		return
+	}
+	e, ar := andConds(a.on).Expr(d)
+	expr += " ON " + e
+	args = append(args, ar...)
 	return
 }
 
@@ -311,7 +564,7 @@ type anyVal struct {
 	any
 }
 
-func (a anyVal) Expr() (expr string, args []any) {
+func (a anyVal) Expr(d Dialect) (expr string, args []any) {
 	expr = "?"
 	args = []any{a.any}
 	return
@@ -319,7 +572,7 @@ func (a anyVal) Expr() (expr string, args []any) {
 
 type anyValList []any
 
-func (a anyValList) Expr() (expr string, args []any) {
+func (a anyValList) Expr(d Dialect) (expr string, args []any) {
 	expr = strings.Repeat("?,", len(a))
 	expr = expr[:len(expr)-1]
 	args = a
@@ -330,9 +583,82 @@ type brackets struct {
 	ExprIfc
 }
 
-func (b brackets) Expr() (expr string, args []any) {
-	e, a := b.ExprIfc.Expr()
+func (b brackets) Expr(d Dialect) (expr string, args []any) {
+	e, a := b.ExprIfc.Expr(d)
 	expr = "(" + e + ")"
 	args = a
 	return
 }
+
+type arithExpr struct {
+	left  ExprIfc
+	op    string
+	right ExprIfc
+}
+
+func (a *arithExpr) Expr(d Dialect) (expr string, args []any) {
+	leftE, leftA := a.left.Expr(d)
+	rightE, rightA := a.right.Expr(d)
+	expr = fmt.Sprintf("%s %s %s", leftE, a.op, rightE)
+	args = append(args, leftA...)
+	args = append(args, rightA...)
+	return
+}
+
+type nullExpr struct{}
+
+func (nullExpr) Expr(d Dialect) (expr string, args []any) {
+	expr = "NULL"
+	return
+}
+
+// likeEscape is the escape character of the patterns built by Contains,
+// StartsWith and EndsWith, a backslash needs escaping in mysql literals
+const likeEscape = "!"
+
+var likeEscaper = strings.NewReplacer(
+	likeEscape, likeEscape+likeEscape,
+	"%", likeEscape+"%",
+	"_", likeEscape+"_",
+)
+
+// likePattern is a LIKE pattern matching val literally, wrapped by prefix
+// and suffix wildcards
+type likePattern struct {
+	val    string
+	prefix string
+	suffix string
+}
+
+func (l likePattern) Expr(d Dialect) (expr string, args []any) {
+	expr = "? ESCAPE '" + likeEscape + "'"
+	args = append(args, l.prefix+likeEscaper.Replace(l.val)+l.suffix)
+	return
+}
+
+type betweenExpr struct {
+	lo any
+	hi any
+}
+
+func (b betweenExpr) Expr(d Dialect) (expr string, args []any) {
+	expr = "? AND ?"
+	args = append(args, b.lo, b.hi)
+	return
+}
+
+// dialectOpExpr renders an operator that differs between dialects
+type dialectOpExpr struct {
+	left  ExprIfc
+	right ExprIfc
+	opFn  func(d Dialect, left, right string) string
+}
+
+func (o *dialectOpExpr) Expr(d Dialect) (expr string, args []any) {
+	leftE, leftA := o.left.Expr(d)
+	rightE, rightA := o.right.Expr(d)
+	expr = o.opFn(d, leftE, rightE)
+	args = append(args, leftA...)
+	args = append(args, rightA...)
+	return
+}
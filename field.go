@@ -7,8 +7,8 @@ import (
 var _ FieldIfc = (*Field[any])(nil)
 
 type FieldIfc interface {
-	ColName(withEscape bool) string
-	DBColName(withEscape bool) string
+	ColName(d Dialect) string
+	DBColName(d Dialect) string
 	IsAutoIncrement() bool
 	ExprIfc
 
@@ -29,6 +29,14 @@ func NewField[T any](name string, schema Schema) *Field[T] {
 	}
 }
 
+func (f *Field[T]) setSchema(s Schema) {
+	f.Schema = s
+}
+
+func (f *Field[T]) getSchema() Schema {
+	return f.Schema
+}
+
 func (f *Field[T]) SetAutoIncrement(b bool) {
 	f.AutoIncrement = b
 }
@@ -41,22 +49,24 @@ func (f Field[T]) key() string {
 	return fmt.Sprintf("%s.%s", f.Schema.TableName(), f.Name)
 }
 
-func (f Field[T]) ColName(withEscape bool) string {
+// ColName returns the column name, quoted by d if d is not nil
+func (f Field[T]) ColName(d Dialect) string {
 	wrapFn := func(s string) string {
 		return s
 	}
-	if withEscape {
-		wrapFn = FieldWrapper
+	if d != nil {
+		wrapFn = d.Quote
 	}
 	return wrapFn(f.Name)
 }
 
-func (f Field[T]) DBColName(withEscape bool) string {
+// DBColName returns the column name with the table name, quoted by d if d is not nil
+func (f Field[T]) DBColName(d Dialect) string {
 	wrapFn := func(s string) string {
 		return s
 	}
-	if withEscape {
-		wrapFn = FieldWrapper
+	if d != nil {
+		wrapFn = d.Quote
 	}
 	return fmt.Sprintf("%s.%s",
 		wrapFn(f.Schema.TableName()),
@@ -64,8 +74,8 @@ func (f Field[T]) DBColName(withEscape bool) string {
 	)
 }
 
-func (f Field[T]) Expr() (string, []any) {
-	return f.DBColName(true), []any{}
+func (f Field[T]) Expr(d Dialect) (string, []any) {
+	return f.DBColName(d), []any{}
 }
 
 func (f Field[T]) Eq(val T) Cond {
@@ -161,6 +171,88 @@ func (f Field[T]) IsNull(isNull bool) Cond {
 	}
 }
 
+// Like matches the field to pattern, the wildcards of pattern are kept
+func (f Field[T]) Like(pattern string) Cond {
+	return Cond{
+		left:  &f,
+		Op:    "LIKE",
+		right: anyVal{pattern},
+	}
+}
+
+func (f Field[T]) NotLike(pattern string) Cond {
+	return Cond{
+		left:  &f,
+		Op:    "NOT LIKE",
+		right: anyVal{pattern},
+	}
+}
+
+// ILike matches the field to pattern case-insensitively
+func (f Field[T]) ILike(pattern string) Cond {
+	return Cond{
+		left: &dialectOpExpr{
+			left:  &f,
+			right: anyVal{pattern},
+			opFn:  Dialect.ILike,
+		},
+	}
+}
+
+// Contains matches the field containing s, the wildcards in s are escaped
+func (f Field[T]) Contains(s string) Cond {
+	return Cond{
+		left:  &f,
+		Op:    "LIKE",
+		right: likePattern{val: s, prefix: "%", suffix: "%"},
+	}
+}
+
+// StartsWith matches the field starting with s, the wildcards in s are escaped
+func (f Field[T]) StartsWith(s string) Cond {
+	return Cond{
+		left:  &f,
+		Op:    "LIKE",
+		right: likePattern{val: s, suffix: "%"},
+	}
+}
+
+// EndsWith matches the field ending with s, the wildcards in s are escaped
+func (f Field[T]) EndsWith(s string) Cond {
+	return Cond{
+		left:  &f,
+		Op:    "LIKE",
+		right: likePattern{val: s, prefix: "%"},
+	}
+}
+
+func (f Field[T]) Between(lo, hi T) Cond {
+	return Cond{
+		left:  &f,
+		Op:    "BETWEEN",
+		right: betweenExpr{lo: lo, hi: hi},
+	}
+}
+
+func (f Field[T]) NotBetween(lo, hi T) Cond {
+	return Cond{
+		left:  &f,
+		Op:    "NOT BETWEEN",
+		right: betweenExpr{lo: lo, hi: hi},
+	}
+}
+
+// Regexp matches the field to the regular expression pattern
+func (f Field[T]) Regexp(pattern string) Cond {
+	return Cond{
+		left: &dialectOpExpr{
+			left:  &f,
+			right: anyVal{pattern},
+			opFn:  Dialect.Regexp,
+		},
+	}
+}
+
 func (f Field[T]) Gt(val T) Cond {
 	return Cond{
 		left:  &f,
@@ -225,6 +317,59 @@ func (f Field[T]) LteCol(col FieldIfc) Cond {
 	}
 }
 
+// Incr assigns the field to itself plus n in update
+func (f Field[T]) Incr(n T) Cond {
+	return Cond{
+		left: &f,
+		Op:   "=",
+		right: &arithExpr{
+			left:  &f,
+			op:    "+",
+			right: anyVal{n},
+		},
+	}
+}
+
+// Decr assigns the field to itself minus n in update
+func (f Field[T]) Decr(n T) Cond {
+	return Cond{
+		left: &f,
+		Op:   "=",
+		right: &arithExpr{
+			left:  &f,
+			op:    "-",
+			right: anyVal{n},
+		},
+	}
+}
+
+// SetExpr assigns the field to expr in update
+func (f Field[T]) SetExpr(expr ExprIfc) Cond {
+	return Cond{
+		left:  &f,
+		Op:    "=",
+		right: expr,
+	}
+}
+
+// SetNull assigns the field to NULL in update
+func (f Field[T]) SetNull() Cond {
+	return Cond{
+		left:  &f,
+		Op:    "=",
+		right: nullExpr{},
+	}
+}
+
+// SetCol assigns the field to the value of col in update
+func (f Field[T]) SetCol(col FieldIfc) Cond {
+	return Cond{
+		left:  &f,
+		Op:    "=",
+		right: col,
+	}
+}
+
 func (f Field[T]) Desc(desc bool) Order {
 	return Order{
 		Field: &f,
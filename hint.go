@@ -0,0 +1,83 @@
+package orm
+
+import (
+	"fmt"
+	"strings"
+)
+
+// indexHint is a mysql index hint of a table in select
+type indexHint struct {
+	schema Schema
+	kind   string
+	names  []string
+}
+
+// indexHintsExpr renders the hints following the table, nothing if the
+// dialect doesn't support them
+func indexHintsExpr(d Dialect, hints []indexHint) string {
+	if len(hints) == 0 || !d.SupportsIndexHints() {
+		return ""
+	}
+	exprs := []string{}
+	for _, hint := range hints {
+		names := []string{}
+		for _, name := range hint.names {
+			names = append(names, d.Quote(name))
+		}
+		exprs = append(exprs, hint.kind+" INDEX ("+strings.Join(names, ", ")+")")
+	}
+	return " " + strings.Join(exprs, " ")
+}
+
+// UseIndex hints the indexes to use for the table of schema, either the
+// table of the stmt or a joined one. Only mysql accepts index hints
+func (a *Stmt) UseIndex(schema Schema, name ...string) *Stmt {
+	return a.indexHint(schema, "USE", name)
+}
+
+// ForceIndex hints the indexes to use instead of a table scan
+func (a *Stmt) ForceIndex(schema Schema, name ...string) *Stmt {
+	return a.indexHint(schema, "FORCE", name)
+}
+
+// IgnoreIndex hints the indexes not to use
+func (a *Stmt) IgnoreIndex(schema Schema, name ...string) *Stmt {
+	return a.indexHint(schema, "IGNORE", name)
+}
+
+func (a *Stmt) indexHint(schema Schema, kind string, names []string) *Stmt {
+	a.hints = append(a.hints, indexHint{schema: schema, kind: kind, names: names})
+	return a
+}
+
+// hintsOf returns the hints of the table of schema
+func (a *Stmt) hintsOf(schema Schema) []indexHint {
+	if len(a.hints) == 0 {
+		return nil
+	}
+	hints := []indexHint{}
+	for _, hint := range a.hints {
+		if tableName(hint.schema) == tableName(schema) {
+			hints = append(hints, hint)
+		}
+	}
+	return hints
+}
+
+func (a *Stmt) hasIndexHints() bool {
+	return len(a.hints) > 0
+}
+
+// checkIndexHints checks the hints are of the tables in the select
+func (a *Stmt) checkIndexHints() error {
+	for _, hint := range a.hints {
+		found := tableName(hint.schema) == tableName(a.schema)
+		for _, join := range a.joins {
+			found = found || tableName(hint.schema) == tableName(join.schema)
+		}
+		if !found {
+			return fmt.Errorf("index hint of %s not in the select", tableName(hint.schema))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package orm
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"strings"
+)
+
+var _ FieldIfc = (*JSONPath)(nil)
+
+// JSONPath is the value as text at a path of a json column, it works as a
+// field in select, order by and conditions
+type JSONPath struct {
+	typedOps[any]
+	field FieldIfc
+	path  string
+}
+
+// JSONPath references the value at path such as $.key or $.list[0] of the json column
+func (f Field[T]) JSONPath(path string) *JSONPath {
+	p := &JSONPath{
+		field: &f,
+		path:  path,
+	}
+	p.typedOps = typedOps[any]{self: p}
+	return p
+}
+
+// JSONContains matches the json column containing the json document of doc
+func (f Field[T]) JSONContains(doc any) Cond {
+	return Cond{
+		left: &dialectOpExpr{
+			left:  &f,
+			right: anyVal{jsonValue{doc}},
+			opFn:  Dialect.JSONContains,
+		},
+	}
+}
+
+// JSONArrayContains matches the json array column containing the element val
+func (f Field[T]) JSONArrayContains(val any) Cond {
+	return f.JSONContains([]any{val})
+}
+
+// JSONSet assigns val to path of the json column in update
+func (f Field[T]) JSONSet(path string, val any) Cond {
+	return Cond{
+		left: &f,
+		Op:   "=",
+		right: &dialectOpExpr{
+			left:  &f,
+			right: anyVal{jsonValue{val}},
+			opFn: func(d Dialect, left, right string) string {
+				return d.JSONSet(left, path, right)
+			},
+		},
+	}
+}
+
+func (p *JSONPath) computed() {}
+
+func (p *JSONPath) IsAutoIncrement() bool {
+	return false
+}
+
+func (p *JSONPath) key() string {
+	return p.field.key() + "->" + p.path
+}
+
+func (p *JSONPath) ColName(d Dialect) string {
+	if d == nil {
+		return p.key()
+	}
+	return d.JSONExtract(p.field.ColName(d), p.path)
+}
+
+func (p *JSONPath) DBColName(d Dialect) string {
+	if d == nil {
+		return p.key()
+	}
+	return d.JSONExtract(p.field.DBColName(d), p.path)
+}
+
+func (p *JSONPath) Expr(d Dialect) (string, []any) {
+	return p.DBColName(d), []any{}
+}
+
+// jsonValue binds the json encoding of the value
+type jsonValue struct {
+	val any
+}
+
+func (v jsonValue) Value() (driver.Value, error) {
+	b, err := json.Marshal(v.val)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+type jsonPathStep struct {
+	name  string
+	index bool
+}
+
+// jsonPathSteps splits the path such as $.a."b c"[0] into the keys and
+// array indexes
+func jsonPathSteps(path string) []jsonPathStep {
+	steps := []jsonPathStep{}
+	path = strings.TrimPrefix(path, "$")
+	for len(path) > 0 {
+		switch path[0] {
+		case '.':
+			path = path[1:]
+			if strings.HasPrefix(path, `"`) {
+				end := strings.Index(path[1:], `"`)
+				if end < 0 {
+					end = len(path) - 1
+				}
+				steps = append(steps, jsonPathStep{name: path[1 : end+1]})
+				path = path[min(end+2, len(path)):]
+				continue
+			}
+			end := strings.IndexAny(path, ".[")
+			if end < 0 {
+				end = len(path)
+			}
+			steps = append(steps, jsonPathStep{name: path[:end]})
+			path = path[end:]
+		case '[':
+			end := strings.Index(path, "]")
+			if end < 0 {
+				end = len(path)
+			}
+			// only digits are pasted as an index, anything else is quoted as a key
+			name := path[1:end]
+			steps = append(steps, jsonPathStep{name: name, index: isDigits(name)})
+			path = path[min(end+1, len(path)):]
+		default:
+			// not a path, take the rest as a key
+			steps = append(steps, jsonPathStep{name: path})
+			path = ""
+		}
+	}
+	return steps
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
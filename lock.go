@@ -0,0 +1,67 @@
+package orm
+
+import (
+	"errors"
+	"strings"
+)
+
+var _ ExprIfc = (*lockExpr)(nil)
+
+// lockExpr is the locking clause of select, it works in a transaction only
+type lockExpr struct {
+	strength string
+	of       []Schema
+	wait     string
+}
+
+func (a *lockExpr) Expr(d Dialect) (expr string, args []any) {
+	expr = "FOR " + a.strength
+	if len(a.of) > 0 {
+		tables := []string{}
+		for _, schema := range a.of {
+			tables = append(tables, d.Quote(tableName(schema)))
+		}
+		expr += " OF " + strings.Join(tables, ", ")
+	}
+	if a.wait != "" {
+		expr += " " + a.wait
+	}
+	return
+}
+
+// ForUpdate locks the selected rows against update and lock by others
+func (a *Stmt) ForUpdate() *Stmt {
+	a.lock = &lockExpr{strength: "UPDATE"}
+	return a
+}
+
+// ForUpdateOf locks the selected rows of the schemas only
+func (a *Stmt) ForUpdateOf(schema ...Schema) *Stmt {
+	a.lock = &lockExpr{strength: "UPDATE", of: schema}
+	return a
+}
+
+// ForShare locks the selected rows against update by others
+func (a *Stmt) ForShare() *Stmt {
+	a.lock = &lockExpr{strength: "SHARE"}
+	return a
+}
+
+// SkipLocked skips the rows locked by others instead of waiting
+func (a *Stmt) SkipLocked() *Stmt {
+	return a.lockWait("SKIP LOCKED")
+}
+
+// NoWait fails instead of waiting for the rows locked by others
+func (a *Stmt) NoWait() *Stmt {
+	return a.lockWait("NOWAIT")
+}
+
+func (a *Stmt) lockWait(wait string) *Stmt {
+	if a.lock == nil {
+		a.err = errors.New(wait + " requires ForUpdate or ForShare")
+		return a
+	}
+	a.lock.wait = wait
+	return a
+}
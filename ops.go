@@ -0,0 +1,146 @@
+package orm
+
+// typedOps are the conditions and orders of an expression that works as a
+// field, self renders the left side
+type typedOps[T any] struct {
+	self FieldIfc
+}
+
+func (o typedOps[T]) Eq(val T) Cond {
+	return Cond{
+		left:  o.self,
+		Op:    "=",
+		right: anyVal{val},
+	}
+}
+
+func (o typedOps[T]) EqCol(col FieldIfc) Cond {
+	return Cond{
+		left:  o.self,
+		Op:    "=",
+		right: col,
+	}
+}
+
+func (o typedOps[T]) NotEq(val T) Cond {
+	return Cond{
+		left:  o.self,
+		Op:    "<>",
+		right: anyVal{val},
+	}
+}
+
+func (o typedOps[T]) In(val ...T) Cond {
+	anyList := []any{}
+	for _, v := range val {
+		anyList = append(anyList, v)
+	}
+	return Cond{
+		left:  o.self,
+		Op:    "IN",
+		right: brackets{anyValList(anyList)},
+	}
+}
+
+func (o typedOps[T]) NotIn(val ...T) Cond {
+	anyList := []any{}
+	for _, v := range val {
+		anyList = append(anyList, v)
+	}
+	return Cond{
+		left:  o.self,
+		Op:    "NOT IN",
+		right: brackets{anyValList(anyList)},
+	}
+}
+
+func (o typedOps[T]) IsNull(isNull bool) Cond {
+	if isNull {
+		return Cond{
+			left: o.self,
+			Op:   "IS NULL",
+		}
+	}
+	return Cond{
+		left: o.self,
+		Op:   "IS NOT NULL",
+	}
+}
+
+func (o typedOps[T]) Gt(val T) Cond {
+	return Cond{
+		left:  o.self,
+		Op:    ">",
+		right: anyVal{val},
+	}
+}
+
+func (o typedOps[T]) Gte(val T) Cond {
+	return Cond{
+		left:  o.self,
+		Op:    ">=",
+		right: anyVal{val},
+	}
+}
+
+func (o typedOps[T]) Lt(val T) Cond {
+	return Cond{
+		left:  o.self,
+		Op:    "<",
+		right: anyVal{val},
+	}
+}
+
+func (o typedOps[T]) Lte(val T) Cond {
+	return Cond{
+		left:  o.self,
+		Op:    "<=",
+		right: anyVal{val},
+	}
+}
+
+func (o typedOps[T]) GtCol(col FieldIfc) Cond {
+	return Cond{
+		left:  o.self,
+		Op:    ">",
+		right: col,
+	}
+}
+
+func (o typedOps[T]) GteCol(col FieldIfc) Cond {
+	return Cond{
+		left:  o.self,
+		Op:    ">=",
+		right: col,
+	}
+}
+
+func (o typedOps[T]) LtCol(col FieldIfc) Cond {
+	return Cond{
+		left:  o.self,
+		Op:    "<",
+		right: col,
+	}
+}
+
+func (o typedOps[T]) LteCol(col FieldIfc) Cond {
+	return Cond{
+		left:  o.self,
+		Op:    "<=",
+		right: col,
+	}
+}
+
+func (o typedOps[T]) Desc(desc bool) Order {
+	return Order{
+		Field: o.self,
+		Desc:  desc,
+	}
+}
+
+func (o typedOps[T]) Asc() Order {
+	return Order{
+		Field: o.self,
+		Desc:  false,
+	}
+}
@@ -74,6 +74,11 @@ func BindFieldIfc(ref any, f FieldIfc, base *PayloadBase) {
 	base.BindField(ref, f)
 }
 
+// BindExpr binds ref to the value of an arbitrary select expression
+func BindExpr(ref any, expr ExprIfc, base *PayloadBase) {
+	base.BindField(ref, &exprField{expr: expr})
+}
+
 func boundFields(p PayloadIfc) []*fieldBind {
 	p.Bind()
 	// TODO: 查找嵌套的结构中的 PayloadIfc
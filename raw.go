@@ -0,0 +1,168 @@
+package orm
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+)
+
+var _ FieldIfc = (*RawExpr)(nil)
+var _ Schema = (*RawExpr)(nil)
+
+// RawExpr is a hand-written sql fragment, it works as an expression, a
+// field in select and order by, a condition by Cond and a join target.
+// Write the placeholders as `?`, they're rebound for the dialect
+type RawExpr struct {
+	typedOps[any]
+	sql  string
+	args []any
+}
+
+// Raw is the sql fragment with args bound to its placeholders
+func Raw(sql string, args ...any) *RawExpr {
+	r := &RawExpr{
+		sql:  sql,
+		args: args,
+	}
+	r.typedOps = typedOps[any]{self: r}
+	return r
+}
+
+// Cond uses the fragment as a condition
+func (r *RawExpr) Cond() Cond {
+	return Cond{
+		left: r,
+	}
+}
+
+func (r *RawExpr) computed() {}
+
+func (r *RawExpr) selectExpr(d Dialect, withTableName bool) (string, []any) {
+	return r.Expr(d)
+}
+
+func (r *RawExpr) IsAutoIncrement() bool {
+	return false
+}
+
+func (r *RawExpr) key() string {
+	return exprKey(r)
+}
+
+func (r *RawExpr) ColName(d Dialect) string {
+	return r.sql
+}
+
+func (r *RawExpr) DBColName(d Dialect) string {
+	return r.sql
+}
+
+func (r *RawExpr) Expr(d Dialect) (expr string, args []any) {
+	expr = r.sql
+	args = append(args, r.args...)
+	return
+}
+
+// TableName the fragment as a join target
+func (r *RawExpr) TableName() string {
+	return r.sql
+}
+
+func (r *RawExpr) IDField() FieldIfc {
+	return nil
+}
+
+// RawQuery is a hand-written statement run by the session, the columns of
+// the result are matched to the bound fields of payloads by column name
+type RawQuery struct {
+	ctx     context.Context
+	session *Session
+	expr    *RawExpr
+}
+
+// Raw runs the hand-written sql with args, write the placeholders as `?`
+func (s *Session) Raw(ctx context.Context, sql string, args ...any) *RawQuery {
+	return &RawQuery{
+		ctx:     ctx,
+		session: s,
+		expr:    Raw(sql, args...),
+	}
+}
+
+// TakePayload scans the first row into payload, ErrNotFund if no row
+func (q *RawQuery) TakePayload(payload PayloadIfc, nestedPayload ...any) error {
+	bindFields, err := payloadFields(payload, nestedPayload...)
+	if err != nil {
+		return err
+	}
+	found := false
+	err = q.session.query(q.ctx, q.expr, func(rows *sql.Rows) error {
+		found = true
+		columns, err := rows.Columns()
+		if err != nil {
+			return err
+		}
+		if err := scanPayload(rows, scanValuesByName(columns, bindFields), bindFields); err != nil {
+			return err
+		}
+		return errStopRows
+	})
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrNotFund
+	}
+	return nil
+}
+
+// FindPayload scans the rows into the slice of payloads
+func (q *RawQuery) FindPayload(payloadsRef any) error {
+	rvElem, newFn, err := payloadSlice(payloadsRef)
+	if err != nil {
+		return err
+	}
+	var columns []string
+	return q.session.query(q.ctx, q.expr, func(rows *sql.Rows) (err error) {
+		if columns == nil {
+			if columns, err = rows.Columns(); err != nil {
+				return
+			}
+		}
+		rvPayload, p, err := newFn()
+		if err != nil {
+			return
+		}
+		bindFields := boundFields(p)
+		if err = scanPayload(rows, scanValuesByName(columns, bindFields), bindFields); err != nil {
+			return
+		}
+		rvElem.Set(reflect.Append(rvElem, rvPayload))
+		return
+	})
+}
+
+// Scan scans the columns of the first row into dest, ErrNotFund if no row
+func (q *RawQuery) Scan(dest ...any) error {
+	found := false
+	err := q.session.query(q.ctx, q.expr, func(rows *sql.Rows) error {
+		found = true
+		if err := rows.Scan(dest...); err != nil {
+			return err
+		}
+		return errStopRows
+	})
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrNotFund
+	}
+	return nil
+}
+
+// Exec executes the statement without returning rows
+func (q *RawQuery) Exec() (sql.Result, error) {
+	sqlRaw, argsRaw := q.session.build(q.expr)
+	return q.session.db.ExecContext(q.ctx, sqlRaw, argsRaw...)
+}
@@ -4,3 +4,21 @@ type Schema interface {
 	TableName() string
 	IDField() FieldIfc
 }
+
+// Table is a schema known by the name only, such as a common table
+// expression, define its fields by NewField
+type Table struct {
+	name string
+}
+
+func NewTable(name string) *Table {
+	return &Table{name: name}
+}
+
+func (t *Table) TableName() string {
+	return t.name
+}
+
+func (t *Table) IDField() FieldIfc {
+	return nil
+}
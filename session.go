@@ -3,12 +3,18 @@ package orm
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"reflect"
 )
 
 type Session struct {
-	db ExecutorIfc
+	db      ExecutorIfc
+	dialect Dialect
+	// tx the session runs in a transaction
+	tx bool
+	// strict fails the features not supported by the dialect instead of ignoring them
+	strict bool
 }
 
 func (s *Session) Table(schema Schema) *Action {
@@ -18,15 +24,187 @@ func (s *Session) Table(schema Schema) *Action {
 	}
 }
 
+// From selects from the derived table of query named alias
+func (s *Session) From(query *Stmt, alias string) *Action {
+	return s.Table(Derived(query, alias))
+}
+
+// Dialect returns the dialect used to render statements, MySQL by default
+func (s *Session) Dialect() Dialect {
+	if s.dialect == nil {
+		return MySQL
+	}
+	return s.dialect
+}
+
+// build renders expr to sql and args for the session dialect
+func (s *Session) build(expr ExprIfc) (string, []any) {
+	d := s.Dialect()
+	sqlRaw, args := expr.Expr(d)
+	return rebind(d, sqlRaw), args
+}
+
 var payloadIfcType = reflect.TypeOf((*PayloadIfc)(nil)).Elem()
 
-func (s *Session) queryPayload(ctx context.Context, stmt *Stmt, payloadRef PayloadIfc, nestedPayloadRef ...any) error {
+// checkSelect checks the select features of stmt are available to the session
+func (s *Session) checkSelect(stmt *Stmt) error {
+	d := s.Dialect()
+	if stmt.hasFullJoin() && !d.SupportsFullJoin() {
+		return fmt.Errorf("%w: FULL JOIN on %s", ErrNotSupported, d.Name())
+	}
+	if len(stmt.distinctOn) > 0 && !d.SupportsDistinctOn() {
+		return fmt.Errorf("%w: DISTINCT ON on %s", ErrNotSupported, d.Name())
+	}
+	if stmt.hasCompoundParens() && !d.SupportsCompoundParens() {
+		return fmt.Errorf("%w: order by or limit in a compound member on %s", ErrNotSupported, d.Name())
+	}
+	if s.strict && stmt.hasIndexHints() && !d.SupportsIndexHints() {
+		return fmt.Errorf("%w: index hints on %s", ErrNotSupported, d.Name())
+	}
+	if err := stmt.checkIndexHints(); err != nil {
+		return err
+	}
+	if stmt.lock != nil {
+		if !s.tx {
+			return errors.New("locking rows outside of a transaction")
+		}
+		if !d.SupportsRowLock() {
+			return fmt.Errorf("%w: row locking on %s", ErrNotSupported, d.Name())
+		}
+	}
+	return nil
+}
+
+// count queries the number of rows selected by stmt
+func (s *Session) count(ctx context.Context, stmt *Stmt) (cnt int64, err error) {
+	if err = s.checkSelect(stmt); err != nil {
+		return
+	}
+	expr, err := stmt.completeCount()
+	if err != nil {
+		return
+	}
+	err = s.query(ctx, expr, func(rows *sql.Rows) error {
+		return rows.Scan(&cnt)
+	})
+	return
+}
+
+// completePayload completes stmt to query the fields of a payload, a select
+// of the fields unless the stmt is a compound or returns the rows by RETURNING
+func (s *Session) completePayload(stmt *Stmt, fields []FieldIfc) (ExprIfc, []FieldIfc, error) {
+	if stmt.compound != nil {
+		for _, item := range stmt.compound.stmts {
+			if len(item.selectField) == 0 {
+				item.selectField = fields
+			}
+		}
+		if err := s.checkSelect(stmt); err != nil {
+			return nil, nil, err
+		}
+		expr, err := stmt.completeCompound()
+		return expr, compoundColumns(stmt.columns(), fields), err
+	}
+	d := s.Dialect()
+	if stmt.returning == nil {
+		if err := s.checkSelect(stmt); err != nil {
+			return nil, nil, err
+		}
+		stmt.selectField = fields
+		expr, err := stmt.completeSelect()
+		return expr, fields, err
+	}
+	if !d.SupportsReturning() {
+		return nil, nil, fmt.Errorf("%w: RETURNING on %s", ErrNotSupported, d.Name())
+	}
+	if len(stmt.returning.fields) == 0 {
+		stmt.returning.fields = fields
+	}
+	expr, err := stmt.complete()
+	return expr, stmt.returning.fields, err
+}
+
+// compoundColumns maps the columns of a compound to the fields, the
+// selects of a compound may be of other tables than the payload. A column
+// goes to the field of the same column name, the rest go to the remaining
+// fields in bound order
+func compoundColumns(columns []FieldIfc, fields []FieldIfc) []FieldIfc {
+	mapped := make([]FieldIfc, len(columns))
+	used := make([]bool, len(fields))
+	for i, column := range columns {
+		if isComputed(column) {
+			continue
+		}
+		for j, field := range fields {
+			if !used[j] && !isComputed(field) && field.ColName(nil) == column.ColName(nil) {
+				mapped[i], used[j] = field, true
+				break
+			}
+		}
+	}
+	j := 0
+	for i, column := range columns {
+		if mapped[i] != nil {
+			continue
+		}
+		for j < len(fields) && used[j] {
+			j++
+		}
+		if j < len(fields) {
+			mapped[i], used[j] = fields[j], true
+		} else {
+			mapped[i] = column
+		}
+	}
+	return mapped
+}
+
+// scanValues returns the scan destinations of the columns, matched to the
+// bound fields by key, the columns not bound are discarded
+func scanValues(columns []FieldIfc, bindFields []*fieldBind) []any {
+	refs := map[string]any{}
+	for _, field := range bindFields {
+		refs[field.field.key()] = field.RefVal()
+	}
+	values := make([]any, 0, len(columns))
+	for _, column := range columns {
+		ref, ok := refs[column.key()]
+		if !ok {
+			ref = new(any)
+		}
+		values = append(values, ref)
+	}
+	return values
+}
+
+// scanValuesByName returns the scan destinations of the columns, matched
+// to the bound fields by column name, the columns of the same name go to
+// the fields in bound order
+func scanValuesByName(columns []string, bindFields []*fieldBind) []any {
+	refs := map[string][]any{}
+	for _, field := range bindFields {
+		name := field.field.ColName(nil)
+		refs[name] = append(refs[name], field.RefVal())
+	}
+	values := make([]any, 0, len(columns))
+	for _, column := range columns {
+		var ref any = new(any)
+		if list := refs[column]; len(list) > 0 {
+			ref, refs[column] = list[0], list[1:]
+		}
+		values = append(values, ref)
+	}
+	return values
+}
+
+// payloadFields returns the bound fields of payloadRef and the nested payloads
+func payloadFields(payloadRef PayloadIfc, nestedPayloadRef ...any) ([]*fieldBind, error) {
 	// TODO: 自动识别 payload 嵌套, 或者使用 nestPayloadRef 指定
 	bindFields := boundFields(payloadRef)
 	for _, item := range nestedPayloadRef {
 		itemV := reflect.ValueOf(item)
 		if itemV.Type().Kind() != reflect.Ptr {
-			return fmt.Errorf("payload must be pointer")
+			return nil, fmt.Errorf("payload must be pointer")
 		}
 		if itemV.Type().Implements(payloadIfcType) {
 			p := item.(PayloadIfc)
@@ -41,87 +219,115 @@ func (s *Session) queryPayload(ctx context.Context, stmt *Stmt, payloadRef Paylo
 			p := itemDef.(PayloadIfc)
 			bindFields = append(bindFields, boundFields(p)...)
 		} else {
-			return fmt.Errorf("nestedPayloadRef must be PayloadIfc, find :%T", item)
+			return nil, fmt.Errorf("nestedPayloadRef must be PayloadIfc, find :%T", item)
 		}
 	}
-	fields := []FieldIfc{}
-	for _, field := range bindFields {
-		fields = append(fields, field.field)
+	return bindFields, nil
+}
+
+// payloadSlice checks payloadSliceRef is a pointer to a slice of payload
+// pointers and returns the slice with the constructor of its payloads
+func payloadSlice(payloadSliceRef any) (reflect.Value, func() (reflect.Value, PayloadIfc, error), error) {
+	rv := reflect.ValueOf(payloadSliceRef)
+	if rv.Kind() != reflect.Ptr {
+		return rv, nil, fmt.Errorf("must be ptr, find :%T", rv.Interface())
 	}
-	stmt.selectField = fields
-	expr, err := stmt.completeSelect()
-	if err != nil {
+	rvElem := rv.Elem()
+	if rvElem.Kind() != reflect.Slice {
+		return rv, nil, fmt.Errorf("must be slice, find :%T", rvElem.Interface())
+	}
+	newFn := func() (reflect.Value, PayloadIfc, error) {
+		rvPayload := reflect.New(rvElem.Type().Elem().Elem())
+		p, ok := rvPayload.Interface().(PayloadIfc)
+		if !ok {
+			return rvPayload, nil, fmt.Errorf("must be PayloadIfc, find :%T", rvPayload.Interface())
+		}
+		return rvPayload, p, nil
+	}
+	return rvElem, newFn, nil
+}
+
+// scanPayload scans the current row into the bound fields
+func scanPayload(rows *sql.Rows, values []any, bindFields []*fieldBind) error {
+	if err := rows.Scan(values...); err != nil {
 		return err
 	}
-	sqlRaw, argsRaw := expr.Expr()
+	for _, field := range bindFields {
+		field.setPreVal(field.Val())
+	}
+	return nil
+}
+
+// errStopRows stops query from scanning the rest rows
+var errStopRows = errors.New("stop rows")
+
+// query runs expr and calls fn for every row, fn returns errStopRows to
+// skip the rest rows
+func (s *Session) query(ctx context.Context, expr ExprIfc, fn func(rows *sql.Rows) error) error {
+	sqlRaw, argsRaw := s.build(expr)
 	rows, err := s.db.QueryContext(ctx, sqlRaw, argsRaw...)
 	if err != nil {
 		return err
 	}
+	defer rows.Close()
 	for rows.Next() {
-		values := make([]any, 0, len(bindFields))
-		for _, field := range bindFields {
-			values = append(values, field.RefVal())
-		}
-		if err := rows.Scan(values...); err != nil {
+		if err := fn(rows); err == errStopRows {
+			return nil
+		} else if err != nil {
 			return err
 		}
-		for _, field := range bindFields {
-			field.setPreVal(field.Val())
-		}
 	}
-	return nil
+	return rows.Err()
 }
 
-func (s *Session) queryPayloadSlice(ctx context.Context, stmt *Stmt, payloadSliceRef any) error {
-	rv := reflect.ValueOf(payloadSliceRef)
-	if rv.Kind() != reflect.Ptr {
-		return fmt.Errorf("must be ptr, find :%T", rv.Interface())
-	}
-	rvElem := rv.Elem()
-	if rvElem.Kind() != reflect.Slice {
-		return fmt.Errorf("must be slice, find :%T", rvElem.Interface())
-	}
-	newPayload := reflect.New(rvElem.Type().Elem().Elem())
-	p, ok := newPayload.Interface().(PayloadIfc)
-	if !ok {
-		return fmt.Errorf("must be PayloadIfc, find :%T", newPayload.Interface())
+func (s *Session) queryPayload(ctx context.Context, stmt *Stmt, payloadRef PayloadIfc, nestedPayloadRef ...any) error {
+	bindFields, err := payloadFields(payloadRef, nestedPayloadRef...)
+	if err != nil {
+		return err
 	}
-	bindFields := boundFields(p)
 	fields := []FieldIfc{}
 	for _, field := range bindFields {
 		fields = append(fields, field.field)
 	}
-	stmt.selectField = fields
-	expr, err := stmt.completeSelect()
+	expr, fields, err := s.completePayload(stmt, fields)
 	if err != nil {
 		return err
 	}
-	sqlRaw, argsRaw := expr.Expr()
-	rows, err := s.db.QueryContext(ctx, sqlRaw, argsRaw...)
+	return s.query(ctx, expr, func(rows *sql.Rows) error {
+		return scanPayload(rows, scanValues(fields, bindFields), bindFields)
+	})
+}
+
+func (s *Session) queryPayloadSlice(ctx context.Context, stmt *Stmt, payloadSliceRef any) error {
+	rvElem, newFn, err := payloadSlice(payloadSliceRef)
 	if err != nil {
 		return err
 	}
-	for rows.Next() {
-		rvPayload := reflect.New(rvElem.Type().Elem().Elem())
-		p, ok := rvPayload.Interface().(PayloadIfc)
-		if !ok {
-			return fmt.Errorf("must be PayloadIfc, find :%T", rvPayload.Interface())
+	_, p, err := newFn()
+	if err != nil {
+		return err
+	}
+	bindFields := boundFields(p)
+	fields := []FieldIfc{}
+	for _, field := range bindFields {
+		fields = append(fields, field.field)
+	}
+	expr, fields, err := s.completePayload(stmt, fields)
+	if err != nil {
+		return err
+	}
+	return s.query(ctx, expr, func(rows *sql.Rows) error {
+		rvPayload, p, err := newFn()
+		if err != nil {
+			return err
 		}
 		bindFields := boundFields(p)
-		values := make([]any, 0, len(bindFields))
-		for _, field := range bindFields {
-			values = append(values, field.RefVal())
-		}
-		if err := rows.Scan(values...); err != nil {
+		if err := scanPayload(rows, scanValues(fields, bindFields), bindFields); err != nil {
 			return err
 		}
-		for _, field := range bindFields {
-			field.setPreVal(field.Val())
-		}
 		rvElem.Set(reflect.Append(rvElem, rvPayload))
-	}
-	return nil
+		return nil
+	})
 }
 
 func (s *Session) exec(ctx context.Context, stmt *Stmt) (sql.Result, error) {
@@ -129,6 +335,122 @@ func (s *Session) exec(ctx context.Context, stmt *Stmt) (sql.Result, error) {
 	if err != nil {
 		return nil, err
 	}
-	sqlRaw, argsRaw := expr.Expr()
+	sqlRaw, argsRaw := s.build(expr)
 	return s.db.ExecContext(ctx, sqlRaw, argsRaw...)
 }
+
+// execReturning executes stmt and scans the rows of RETURNING into the
+// payloads of the stmt in order, the last payload receives the rest rows
+func (s *Session) execReturning(ctx context.Context, stmt *Stmt) (int64, error) {
+	if len(stmt.payloads) == 0 {
+		return 0, errors.New("no payload to receive the returning rows")
+	}
+	fields := []FieldIfc{}
+	for _, field := range boundFields(stmt.payloads[0]) {
+		fields = append(fields, field.field)
+	}
+	// the rows of an upsert skip the payloads kept by the conflict, they're
+	// matched to the payloads by the conflict target
+	var match []FieldIfc
+	if stmt.conflict != nil && len(stmt.payloads) > 1 {
+		if len(stmt.conflict.target) == 0 {
+			return 0, errors.New("returning rows of an upsert needs the conflict target to match the payloads")
+		}
+		match = stmt.conflict.target
+		if len(stmt.returning.fields) == 0 {
+			stmt.returning.fields = fields
+		}
+		stmt.returning.fields = appendMissingFields(stmt.returning.fields, match)
+	}
+	expr, fields, err := s.completePayload(stmt, fields)
+	if err != nil {
+		return 0, err
+	}
+	var rowCnt int64
+	err = s.query(ctx, expr, func(rows *sql.Rows) error {
+		if match != nil {
+			rowCnt++
+			return scanMatched(rows, fields, match, stmt.payloads)
+		}
+		idx := int(rowCnt)
+		if idx >= len(stmt.payloads) {
+			idx = len(stmt.payloads) - 1
+		}
+		bindFields := boundFields(stmt.payloads[idx])
+		if err := scanPayload(rows, scanValues(fields, bindFields), bindFields); err != nil {
+			return err
+		}
+		rowCnt++
+		return nil
+	})
+	return rowCnt, err
+}
+
+// appendMissingFields appends the fields not in fields by key
+func appendMissingFields(fields []FieldIfc, more []FieldIfc) []FieldIfc {
+	keys := map[string]bool{}
+	for _, field := range fields {
+		keys[field.key()] = true
+	}
+	for _, field := range more {
+		if !keys[field.key()] {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// scanMatched scans the row into the payload whose match fields equal the
+// columns of the row
+func scanMatched(rows *sql.Rows, columns []FieldIfc, match []FieldIfc, payloads []PayloadIfc) error {
+	refs := map[string]any{}
+	for _, field := range boundFields(payloads[0]) {
+		refs[field.field.key()] = field.RefVal()
+	}
+	values := make([]any, 0, len(columns))
+	for _, column := range columns {
+		var value any = new(any)
+		if ref, ok := refs[column.key()]; ok {
+			// a destination of the type of the bound field
+			value = reflect.New(reflect.TypeOf(ref).Elem()).Interface()
+		}
+		values = append(values, value)
+	}
+	if err := rows.Scan(values...); err != nil {
+		return err
+	}
+	row := map[string]any{}
+	for i, column := range columns {
+		row[column.key()] = reflect.ValueOf(values[i]).Elem().Interface()
+	}
+	for _, payload := range payloads {
+		bindFields := boundFields(payload)
+		if !matchRow(row, match, bindFields) {
+			continue
+		}
+		for _, field := range bindFields {
+			if value, ok := row[field.field.key()]; ok {
+				field.Set(value)
+			}
+			field.setPreVal(field.Val())
+		}
+		return nil
+	}
+	return errors.New("returning row matches none of the payloads")
+}
+
+func matchRow(row map[string]any, match []FieldIfc, bindFields []*fieldBind) bool {
+	for _, m := range match {
+		found := false
+		for _, field := range bindFields {
+			if field.field.key() == m.key() {
+				found = reflect.DeepEqual(row[m.key()], field.Val())
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
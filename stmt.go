@@ -1,6 +1,10 @@
 package orm
 
-import "context"
+import (
+	"context"
+	"errors"
+	"fmt"
+)
 
 type Stmt struct {
 	err           error
@@ -14,10 +18,24 @@ type Stmt struct {
 	conds       []Cond
 	orderBy     []Order
 	groupBy     []FieldIfc
+	having      []Cond
 	sets        []Cond
 	selectField []FieldIfc
 	limit       *limit
 	offset      *offset
+	conflict    *Conflict
+	insertFrom  *Stmt
+	compound    *compound
+	ctes        []cte
+	returning   *returningExpr
+	lock        *lockExpr
+	hints       []indexHint
+	distinct    bool
+	distinctOn  []FieldIfc
+	// payloads receive the rows of returning
+	payloads []PayloadIfc
+	// autoIncrement are read back with RETURNING when the dialect supports it
+	autoIncrement []FieldIfc
 
 	afterExecFn func(row, id int64)
 }
@@ -40,6 +58,12 @@ func (a *Stmt) GroupBy(field ...FieldIfc) *Stmt {
 	return a
 }
 
+// Having filter the groups of group by
+func (a *Stmt) Having(cond ...Cond) *Stmt {
+	a.having = append(a.having, cond...)
+	return a
+}
+
 // Limit set sql limit
 func (a *Stmt) Limit(l int64) *Stmt {
 	a.limit = (*limit)(&l)
@@ -69,6 +93,107 @@ func (a *Stmt) Set(cond ...Cond) *Stmt {
 	return a
 }
 
+// OnConflict starts the upsert clause of an insert, field is the conflict
+// target which mysql ignores
+func (a *Stmt) OnConflict(field ...FieldIfc) *Conflict {
+	return &Conflict{
+		stmt:   a,
+		target: field,
+	}
+}
+
+// Conflict builds the upsert clause of an insert statement, the rows
+// returned by RETURNING are matched to the payloads by the conflict target
+type Conflict struct {
+	stmt      *Stmt
+	target    []FieldIfc
+	updates   []FieldIfc
+	sets      []Cond
+	updateAll bool
+}
+
+// DoNothing keeps the existing row
+func (c *Conflict) DoNothing() *Stmt {
+	c.stmt.conflict = c
+	return c.stmt
+}
+
+// DoUpdate updates the existing row with the assignments, with the values
+// proposed for insertion of all the bound fields except the conflict target
+// and the id if none
+func (c *Conflict) DoUpdate(sets ...Cond) *Stmt {
+	c.sets = append(c.sets, sets...)
+	c.updateAll = len(sets) == 0
+	c.stmt.conflict = c
+	return c.stmt
+}
+
+// DoUpdateFields updates the fields with the values proposed for insertion
+func (c *Conflict) DoUpdateFields(field ...FieldIfc) *Stmt {
+	c.updates = append(c.updates, field...)
+	c.stmt.conflict = c
+	return c.stmt
+}
+
+// doesUpdate reports whether the existing row is updated instead of kept
+func (c *Conflict) doesUpdate() bool {
+	return c.updateAll || len(c.updates) > 0 || len(c.sets) > 0
+}
+
+// checkConflict checks the upsert is valid in the dialect of the session
+func (a *Stmt) checkConflict() error {
+	if a.conflict == nil || a.session == nil {
+		return nil
+	}
+	d := a.session.Dialect()
+	c := a.conflict
+	if len(c.target) == 0 && c.doesUpdate() && !d.SupportsUntargetedUpdate() {
+		return fmt.Errorf("%w: DO UPDATE without the conflict target on %s", ErrNotSupported, d.Name())
+	}
+	return nil
+}
+
+func (c *Conflict) expr() *upsertExpr {
+	var key FieldIfc
+	if c.stmt.schema != nil {
+		key = c.stmt.schema.IDField()
+	}
+	if key == nil && len(c.target) > 0 {
+		key = c.target[0]
+	}
+	if key == nil && len(c.stmt.selectField) > 0 {
+		key = c.stmt.selectField[0]
+	}
+	updates := c.updates
+	if c.updateAll {
+		skip := map[string]bool{}
+		for _, field := range c.target {
+			skip[field.key()] = true
+		}
+		if key != nil {
+			skip[key.key()] = true
+		}
+		for _, field := range c.stmt.selectField {
+			if !skip[field.key()] {
+				updates = append(updates, field)
+			}
+		}
+	}
+	return &upsertExpr{
+		target:  c.target,
+		key:     key,
+		updates: updates,
+		sets:    c.sets,
+	}
+}
+
+// Returning scans the fields of the inserted, updated or deleted rows back
+// into the payloads, all the bound fields of the payload if none
+func (a *Stmt) Returning(field ...FieldIfc) *Stmt {
+	a.returning = &returningExpr{fields: field}
+	return a
+}
+
 func (a *Stmt) Select(field ...FieldIfc) *Stmt {
 	// TODO 去重
 	a.selectField = append(a.selectField, field...)
@@ -92,9 +217,17 @@ func (a *Stmt) LeftJoin(s Schema, on ...Cond) *Stmt {
 	return a
 }
 
+// OuterJoin is FullJoin
+//
+// Deprecated: use FullJoin
 func (a *Stmt) OuterJoin(s Schema, on ...Cond) *Stmt {
+	return a.FullJoin(s, on...)
+}
+
+// FullJoin keeps the rows of both tables, mysql doesn't support it
+func (a *Stmt) FullJoin(s Schema, on ...Cond) *Stmt {
 	a.joins = append(a.joins, joinExpr{
-		tp:     "OUTER",
+		tp:     "FULL",
 		schema: s,
 		on:     on,
 	})
@@ -110,89 +243,277 @@ func (a *Stmt) RightJoin(s Schema, on ...Cond) *Stmt {
 	return a
 }
 
+// CrossJoin joins every row of the table
+func (a *Stmt) CrossJoin(s Schema) *Stmt {
+	a.joins = append(a.joins, joinExpr{
+		tp:     "CROSS",
+		schema: s,
+	})
+	return a
+}
+
+// JoinUsing joins the table on the columns of the same name in both tables
+func (a *Stmt) JoinUsing(s Schema, field ...FieldIfc) *Stmt {
+	a.joins = append(a.joins, joinExpr{
+		schema: s,
+		using:  field,
+	})
+	return a
+}
+
+// JoinSub joins the derived table of query named alias, reference its
+// columns by FieldOf or NewField with NewTable(alias)
+func (a *Stmt) JoinSub(query *Stmt, alias string, on ...Cond) *Stmt {
+	if query.err != nil {
+		a.err = query.err
+	}
+	a.joins = append(a.joins, joinExpr{
+		schema: Derived(query, alias),
+		on:     on,
+	})
+	return a
+}
+
+// Distinct selects the distinct rows only
+func (a *Stmt) Distinct() *Stmt {
+	a.distinct = true
+	return a
+}
+
+// DistinctOn selects the first row of each group of the same fields, order
+// by the fields first to pick the row. Only postgres supports it
+func (a *Stmt) DistinctOn(field ...FieldIfc) *Stmt {
+	a.distinct = true
+	a.distinctOn = append(a.distinctOn, field...)
+	return a
+}
+
+// Count queries the number of rows selected by the stmt, regardless of
+// the order, limit and offset
+func (a *Stmt) Count(ctx context.Context) (int64, error) {
+	return a.session.count(ctx, a)
+}
+
+// completeCount completes the select of the number of rows, the distinct,
+// grouped or compound select is counted by wrapping it as a derived table
+func (a *Stmt) completeCount() (ExprIfc, error) {
+	stmt := *a
+	stmt.orderBy = nil
+	stmt.limit = nil
+	stmt.offset = nil
+	stmt.lock = nil
+	if stmt.compound != nil {
+		for _, item := range stmt.compound.stmts {
+			if len(item.selectField) == 0 {
+				return nil, errors.New("count of a compound needs the fields selected")
+			}
+		}
+	}
+	if stmt.distinct && len(stmt.selectField) == 0 {
+		return nil, errors.New("count of a distinct select needs the fields selected")
+	}
+	wrap := stmt.compound != nil || len(stmt.groupBy) > 0 || len(stmt.distinctOn) > 0
+	if !wrap && stmt.distinct {
+		if len(stmt.selectField) == 1 && !isComputed(stmt.selectField[0]) {
+			stmt.selectField = []FieldIfc{CountDistinct(stmt.selectField[0])}
+			stmt.distinct = false
+			return stmt.completeSelect()
+		}
+		wrap = true
+	}
+	if !wrap {
+		stmt.selectField = []FieldIfc{Count(nil)}
+		return stmt.completeSelect()
+	}
+	if len(stmt.selectField) == 0 {
+		stmt.selectField = stmt.groupBy
+	}
+	// the columns of the derived table need unique names
+	if stmt.compound != nil {
+		first := *stmt.compound.stmts[0]
+		first.selectField = countColumns(first.selectField)
+		stmt.compound = &compound{
+			op:    stmt.compound.op,
+			stmts: append([]*Stmt{&first}, stmt.compound.stmts[1:]...),
+		}
+	} else {
+		stmt.selectField = countColumns(stmt.selectField)
+	}
+	outer := &Stmt{
+		session:     a.session,
+		schema:      Derived(&stmt, "t"),
+		selectField: []FieldIfc{Count(nil)},
+	}
+	return outer.completeSelect()
+}
+
+// countColumns names the fields c1, c2... by position, the fields named by
+// As keep the alias
+func countColumns(fields []FieldIfc) []FieldIfc {
+	columns := make([]FieldIfc, 0, len(fields))
+	for i, field := range fields {
+		if _, ok := field.(*ColumnAlias); ok {
+			columns = append(columns, field)
+			continue
+		}
+		columns = append(columns, As(field, fmt.Sprintf("c%d", i+1)))
+	}
+	return columns
+}
+
+func (a *Stmt) hasFullJoin() bool {
+	for _, join := range a.joins {
+		if join.tp == "FULL" {
+			return true
+		}
+	}
+	return false
+}
+
 func (a *Stmt) completeSelect() (ExprIfc, error) {
+	if t, ok := a.schema.(*DerivedTable); ok && t.query.err != nil && a.err == nil {
+		a.err = t.query.err
+	}
 	action := &selectExpr{
-		fields: a.selectField,
-		schema: a.schema,
+		fields:     a.selectField,
+		schema:     a.schema,
+		hints:      a.hintsOf(a.schema),
+		distinct:   a.distinct,
+		distinctOn: a.distinctOn,
 	}
 	if len(a.joins) > 0 {
 		a.withTableName = true
 	}
 	action.withTableName = a.withTableName
-	exprs := []ExprIfc{action}
-	for _, join := range a.joins {
+	exprs := []ExprIfc{}
+	if len(a.ctes) > 0 {
+		exprs = append(exprs, withExpr(a.ctes))
+	}
+	exprs = append(exprs, action)
+	for i := range a.joins {
+		join := a.joins[i]
+		join.hints = a.hintsOf(join.schema)
 		exprs = append(exprs, &join)
 	}
 	if len(a.conds) > 0 {
 		exprs = append(exprs, Where(a.conds...))
 	}
 	if len(a.groupBy) > 0 {
-		exprs = append(exprs, groupBy(a.groupBy))
+		exprs = append(exprs, groupBy{fields: a.groupBy, withTableName: a.withTableName})
+	}
+	if len(a.having) > 0 {
+		exprs = append(exprs, having(a.having))
 	}
 	if len(a.orderBy) > 0 {
 		exprs = append(exprs, orderBy(a.orderBy))
 	}
-	if a.limit != nil {
-		exprs = append(exprs, a.limit)
+	if a.limit != nil || a.offset != nil {
+		exprs = append(exprs, limitOffset{limit: a.limit, offset: a.offset})
 	}
-	if a.offset != nil {
-		exprs = append(exprs, a.offset)
+	if a.lock != nil {
+		exprs = append(exprs, a.lock)
 	}
 	return ExprSlice(exprs), a.err
 }
 
 func (a *Stmt) completeDelete() (ExprIfc, error) {
+	if err := a.checkJoinedWrite(); err != nil {
+		return nil, err
+	}
 	action := &deleteExpr{
 		schema: a.schema,
+		joins:  a.joins,
+		conds:  a.conds,
 	}
 	exprs := []ExprIfc{action}
-	if len(a.conds) > 0 {
-		exprs = append(exprs, Where(a.conds...))
+	if a.limit != nil || a.offset != nil {
+		exprs = append(exprs, limitOffset{limit: a.limit, offset: a.offset})
 	}
-	if a.limit != nil {
-		exprs = append(exprs, a.limit)
-	}
-	if a.offset != nil {
-		exprs = append(exprs, a.offset)
+	if a.returning != nil {
+		a.returning.withTableName = a.withTableName
+		exprs = append(exprs, a.returning)
 	}
 	return ExprSlice(exprs), a.err
 }
 
 func (a *Stmt) completeInsert() (ExprIfc, error) {
+	if err := a.checkConflict(); err != nil {
+		return nil, err
+	}
 	action := &insertExpr{
 		schema: a.schema,
 		vales:  a.values,
 		fields: a.selectField,
 	}
+	if a.insertFrom != nil {
+		action.query = a.insertFrom.SubQuery()
+	}
 	exprs := []ExprIfc{action}
+	if a.conflict != nil {
+		exprs = append(exprs, a.conflict.expr())
+	}
+	if a.returning != nil {
+		exprs = append(exprs, a.returning)
+	}
 	return ExprSlice(exprs), a.err
 }
 
 func (a *Stmt) completeUpdate() (ExprIfc, error) {
+	if err := a.checkJoinedWrite(); err != nil {
+		return nil, err
+	}
 	// TODO: 根据 select 过滤 set
 	action := &updateExpr{
 		sets:   a.sets,
 		schema: a.schema,
+		joins:  a.joins,
+		conds:  a.conds,
 	}
 	exprs := []ExprIfc{action}
-	if len(a.conds) > 0 {
-		exprs = append(exprs, Where(a.conds...))
-	}
 	if len(a.groupBy) > 0 {
-		exprs = append(exprs, groupBy(a.groupBy))
+		exprs = append(exprs, groupBy{fields: a.groupBy, withTableName: a.withTableName})
 	}
 	if len(a.orderBy) > 0 {
 		exprs = append(exprs, orderBy(a.orderBy))
 	}
-	if a.limit != nil {
-		exprs = append(exprs, a.limit)
+	if a.limit != nil || a.offset != nil {
+		exprs = append(exprs, limitOffset{limit: a.limit, offset: a.offset})
 	}
-	if a.offset != nil {
-		exprs = append(exprs, a.offset)
+	if a.returning != nil {
+		a.returning.withTableName = a.withTableName
+		exprs = append(exprs, a.returning)
 	}
 	return ExprSlice(exprs), a.err
 }
 
+// checkJoinedWrite checks the joins of update and delete are supported,
+// the columns are qualified by the table name if joined
+func (a *Stmt) checkJoinedWrite() error {
+	if len(a.joins) == 0 {
+		return nil
+	}
+	a.withTableName = true
+	if len(a.orderBy) > 0 || a.limit != nil || a.offset != nil {
+		return errors.New("order by and limit are not allowed to join other tables")
+	}
+	if a.session == nil {
+		return nil
+	}
+	d := a.session.Dialect()
+	if d.MultiTableStyle() == MultiTableJoin {
+		return nil
+	}
+	for _, join := range a.joins {
+		if join.tp != "" && join.tp != "CROSS" {
+			return fmt.Errorf("%w: %s JOIN in update and delete on %s", ErrNotSupported, join.tp, d.Name())
+		}
+		if len(join.using) > 0 {
+			return fmt.Errorf("%w: JOIN USING in update and delete on %s", ErrNotSupported, d.Name())
+		}
+	}
+	return nil
+}
+
 func (a *Stmt) complete() (ExprIfc, error) {
 	if a.completeFn != nil {
 		return a.completeFn()
@@ -204,13 +525,28 @@ func (a *Stmt) complete() (ExprIfc, error) {
 }
 
 func (a *Stmt) SubQuery() ExprIfc {
+	if a.compound != nil {
+		expr, _ := a.completeCompound()
+		return expr
+	}
 	expr, _ := a.completeSelect()
 	return expr
 }
 
+// columns returns the selected fields, the fields of the first select of
+// a compound
+func (a *Stmt) columns() []FieldIfc {
+	if a.compound != nil {
+		return a.compound.stmts[0].selectField
+	}
+	return a.selectField
+}
+
 func (a *Stmt) TakePayload(ctx context.Context, payload PayloadIfc, nestedPayload ...any) error {
-	a.limit = new(limit)
-	*a.limit = 1
+	if a.returning == nil {
+		a.limit = new(limit)
+		*a.limit = 1
+	}
 	return a.session.queryPayload(ctx, a, payload, nestedPayload...)
 }
 
@@ -219,11 +555,17 @@ func (a *Stmt) FindPayload(ctx context.Context, payloadsRef any) error {
 }
 
 func (a *Stmt) Do(ctx context.Context) (rowCnt int64, err error) {
-	ret, err := a.session.exec(ctx, a)
-	if err != nil {
-		return
+	if a.err != nil {
+		return 0, a.err
 	}
-	id, err := ret.LastInsertId()
+	if a.returning == nil && len(a.autoIncrement) > 0 && a.session.Dialect().SupportsReturning() {
+		// LastInsertId is not available from every driver, e.g. lib/pq
+		a.returning = &returningExpr{fields: a.autoIncrement}
+	}
+	if a.returning != nil {
+		return a.session.execReturning(ctx, a)
+	}
+	ret, err := a.session.exec(ctx, a)
 	if err != nil {
 		return
 	}
@@ -232,6 +574,11 @@ func (a *Stmt) Do(ctx context.Context) (rowCnt int64, err error) {
 		return
 	}
 	if a.afterExecFn != nil {
+		var id int64
+		id, err = ret.LastInsertId()
+		if err != nil {
+			return
+		}
 		a.afterExecFn(rowCnt, id)
 	}
 	return
@@ -0,0 +1,90 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/archever/orm"
+	"github.com/stretchr/testify/assert"
+)
+
+type teamStatPayload struct {
+	orm.PayloadBase
+	TeamID   int64
+	UserCnt  int64
+	NameCnt  int64
+	MaxID    int64
+	AvgID    float64
+	TotalCnt int64
+}
+
+func (p *teamStatPayload) Bind() {
+	p.PayloadBase.BindField(&p.TeamID, user.TeamID)
+	orm.BindFieldIfc(&p.UserCnt, orm.Count(user.ID), &p.PayloadBase)
+	orm.BindFieldIfc(&p.NameCnt, orm.CountDistinct(user.Name), &p.PayloadBase)
+	orm.BindFieldIfc(&p.MaxID, orm.Max(user.ID), &p.PayloadBase)
+	orm.BindFieldIfc(&p.AvgID, orm.Avg(user.ID), &p.PayloadBase)
+	orm.BindFieldIfc(&p.TotalCnt, orm.Count(nil), &p.PayloadBase)
+}
+
+func Test_Aggregate_Payload(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{}).MustBuild()
+	cli := getClient(m)
+	m.MockDB.ExpectQuery("SELECT `team_id`, COUNT(`id`), COUNT(DISTINCT `name`), MAX(`id`), AVG(`id`), COUNT(*) FROM `user` GROUP BY `team_id` order by COUNT(`user`.`id`) DESC").
+		WillReturnRows(
+			sqlmock.NewRows([]string{"team_id", "cnt", "name_cnt", "max_id", "avg_id", "total"}).
+				AddRow(1, 3, 2, 10, 5.5, 3).
+				AddRow(2, 1, 1, 4, 4, 1),
+		)
+	var payload []*teamStatPayload
+	err := cli.Table(user).Select().
+		GroupBy(user.TeamID).
+		OrderBy(orm.Count(user.ID).Desc(true)).
+		FindPayload(ctx, &payload)
+	assert.NoError(t, err)
+	assert.Len(t, payload, 2)
+	assert.EqualValues(t, 1, payload[0].TeamID)
+	assert.EqualValues(t, 3, payload[0].UserCnt)
+	assert.EqualValues(t, 2, payload[0].NameCnt)
+	assert.EqualValues(t, 10, payload[0].MaxID)
+	assert.EqualValues(t, 5.5, payload[0].AvgID)
+	assert.EqualValues(t, 3, payload[0].TotalCnt)
+}
+
+func Test_Aggregate_SubQuery(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{}).MustBuild()
+	cli := getClient(m)
+	m.MockDB.ExpectQuery("SELECT `id`, `name` FROM `user` WHERE `user`.`id` = (SELECT MAX(`id`) FROM `user` WHERE `user`.`team_id` = ?) LIMIT ?").
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(10, "archever"))
+	subQuery := cli.Table(user).Select(orm.Max(user.ID)).Where(user.TeamID.Eq(1)).SubQuery()
+	var payload userPayload
+	err := cli.Table(user).Select().
+		Where(user.ID.EqQuery(subQuery)).
+		TakePayload(ctx, &payload)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 10, payload.ID)
+}
+
+func Test_Aggregate_Having(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{}).MustBuild()
+	cli := getClient(m)
+	m.MockDB.ExpectQuery("SELECT `id`, `name` FROM `team` WHERE `team`.`id` IN (SELECT `team_id` FROM `user` GROUP BY `team_id` HAVING (COUNT(`user`.`id`) > ? AND (MAX(`user`.`id`) < ? OR MIN(`user`.`id`) = ?)))").
+		WithArgs(5, 100, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "team1"))
+	subQuery := cli.Table(user).Select(user.TeamID).
+		GroupBy(user.TeamID).
+		Having(orm.Count(user.ID).Gt(5)).
+		Having(orm.Or(orm.Max(user.ID).Lt(100), orm.Min(user.ID).Eq(1))).
+		SubQuery()
+	var payload []*teamPayload
+	err := cli.Table(team).Select().
+		Where(team.ID.InQuery(subQuery)).
+		FindPayload(ctx, &payload)
+	assert.NoError(t, err)
+	assert.Len(t, payload, 1)
+}
@@ -0,0 +1,173 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/archever/orm"
+	"github.com/stretchr/testify/assert"
+)
+
+type userCntPayload struct {
+	orm.PayloadBase
+	TeamID  int64
+	Cnt     int64
+	IsFirst bool
+}
+
+func (p *userCntPayload) Bind() {
+	p.PayloadBase.BindField(&p.TeamID, user.TeamID)
+	orm.BindFieldIfc(&p.Cnt, orm.As(orm.Count(nil), "cnt"), &p.PayloadBase)
+	isFirst := orm.Min(user.ID).Eq(1)
+	orm.BindExpr(&p.IsFirst, &isFirst, &p.PayloadBase)
+}
+
+func Test_Alias_Payload(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{}).MustBuild()
+	cli := getClient(m)
+	m.MockDB.ExpectQuery("SELECT `team_id`, COUNT(*) AS `cnt`, MIN(`user`.`id`) = ? FROM `user` GROUP BY `team_id` HAVING `cnt` > ? order by `cnt` DESC").
+		WithArgs(1, 2).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"team_id", "cnt", "is_first"}).AddRow(1, 3, true),
+		)
+	cnt := orm.As(orm.Count(nil), "cnt")
+	var payload []*userCntPayload
+	err := cli.Table(user).Select().
+		GroupBy(user.TeamID).
+		Having(cnt.Gt(2)).
+		OrderBy(cnt.Desc(true)).
+		FindPayload(ctx, &payload)
+	assert.NoError(t, err)
+	assert.Len(t, payload, 1)
+	assert.EqualValues(t, 3, payload[0].Cnt)
+	assert.True(t, payload[0].IsFirst)
+}
+
+func Test_Alias_Select(t *testing.T) {
+	m := (&mockInc{}).MustBuild()
+	cli := getClient(m)
+	subQuery := cli.Table(user).
+		Select(orm.As(user.ID, "uid"), orm.As(team.Name, "team_name")).
+		Join(team, user.TeamID.EqCol(team.ID)).
+		SubQuery()
+	sqlRaw, args := subQuery.Expr(orm.MySQL)
+	assert.Equal(t, "SELECT `user`.`id` AS `uid`, `team`.`name` AS `team_name` FROM `user` JOIN `team` ON `user`.`team_id` = `team`.`id`", sqlRaw)
+	assert.Empty(t, args)
+}
+
+func Test_Alias_InsertSkipComputed(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{}).MustBuild()
+	cli := getClient(m)
+	m.MockDB.ExpectExec("INSERT INTO `user` (`team_id`) VALUES(?)").
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	payload := userCntPayload{TeamID: 1, Cnt: 10}
+	_, err := cli.Table(user).InsertPayload(&payload).Do(ctx)
+	assert.NoError(t, err)
+}
+
+var mate = orm.Alias(user, "mate")
+
+type userMatePayload struct {
+	orm.PayloadBase
+	ID       int64
+	Name     string
+	MateID   int64
+	MateName string
+}
+
+func (p *userMatePayload) Bind() {
+	p.PayloadBase.BindField(&p.ID, user.ID)
+	p.PayloadBase.BindField(&p.Name, user.Name)
+	p.PayloadBase.BindField(&p.MateID, mate.ID)
+	p.PayloadBase.BindField(&p.MateName, mate.Name)
+}
+
+func Test_Alias_SelfJoin(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{}).MustBuild()
+	cli := getClient(m)
+	m.MockDB.ExpectQuery("SELECT `user`.`id`, `user`.`name`, `mate`.`id`, `mate`.`name` FROM `user` JOIN `user` AS `mate` ON (`user`.`team_id` = `mate`.`team_id` AND `user`.`id` <> `mate`.`id`) LEFT JOIN `team` ON `user`.`team_id` = `team`.`id` WHERE `mate`.`name` = ?").
+		WithArgs("archever").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "id", "name"}).AddRow(1, "name1", 2, "archever"))
+	var payload []*userMatePayload
+	err := cli.Table(user).Select().
+		Join(mate, orm.And(user.TeamID.EqCol(mate.TeamID), user.ID.NotEqCol(mate.ID))).
+		LeftJoin(team, user.TeamID.EqCol(team.ID)).
+		Where(mate.Name.Eq("archever")).
+		FindPayload(ctx, &payload)
+	assert.NoError(t, err)
+	assert.Len(t, payload, 1)
+	assert.EqualValues(t, "name1", payload[0].Name)
+	assert.EqualValues(t, 2, payload[0].MateID)
+	assert.EqualValues(t, "archever", payload[0].MateName)
+	// the original schema keeps its table name
+	assert.Equal(t, "`user`.`id`", user.ID.DBColName(orm.MySQL))
+}
+
+type matePayload struct {
+	orm.PayloadBase
+	ID   int64
+	Name string
+}
+
+func (p *matePayload) Bind() {
+	p.PayloadBase.BindField(&p.ID, mate.ID)
+	p.PayloadBase.BindField(&p.Name, mate.Name)
+}
+
+func Test_Alias_From(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{Dialect: orm.Postgres}).MustBuild()
+	cli := getClient(m)
+	m.MockDB.ExpectQuery(`SELECT "id", "name" FROM "user" AS "mate" WHERE "mate"."id" = $1 LIMIT $2`).
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "name1"))
+	var payload matePayload
+	err := cli.Table(mate).Select().Where(mate.ID.Eq(1)).TakePayload(ctx, &payload)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "name1", payload.Name)
+}
+
+type teamFlagsPayload struct {
+	orm.PayloadBase
+	IsFirst  bool
+	IsSecond bool
+}
+
+func (p *teamFlagsPayload) Bind() {
+	isFirst := team.ID.Eq(1)
+	isSecond := team.ID.Eq(2)
+	orm.BindExpr(&p.IsFirst, &isFirst, &p.PayloadBase)
+	orm.BindExpr(&p.IsSecond, &isSecond, &p.PayloadBase)
+}
+
+func Test_Alias_ExprSameSQL(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{}).MustBuild()
+	cli := getClient(m)
+	m.MockDB.ExpectQuery("SELECT `team`.`id` = ?, `team`.`id` = ? FROM `team` LIMIT ?").
+		WithArgs(1, 2, 1).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"is_first", "is_second"}).AddRow(false, true),
+		)
+	var payload teamFlagsPayload
+	err := cli.Table(team).Select().TakePayload(ctx, &payload)
+	assert.NoError(t, err)
+	assert.False(t, payload.IsFirst)
+	assert.True(t, payload.IsSecond)
+}
+
+func Test_Alias_Inline(t *testing.T) {
+	m := (&mockInc{}).MustBuild()
+	cli := getClient(m)
+	boss := orm.Alias(user, "boss")
+	sqlRaw, args := cli.Table(user).Select(user.Name, boss.Name).
+		Join(boss, user.TeamID.EqCol(boss.ID)).
+		SubQuery().Expr(orm.MySQL)
+	assert.Equal(t, "SELECT `user`.`name`, `boss`.`name` FROM `user` JOIN `user` AS `boss` ON `user`.`team_id` = `boss`.`id`", sqlRaw)
+	assert.Empty(t, args)
+}
@@ -0,0 +1,118 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/archever/orm"
+	"github.com/stretchr/testify/assert"
+)
+
+var levelCase = orm.As(
+	orm.Case().
+		When(user.ID.Lt(10), "low").
+		When(user.ID.Lt(100), "mid").
+		Else("high"),
+	"level",
+)
+
+type userLevelPayload struct {
+	orm.PayloadBase
+	ID    int64
+	Level string
+}
+
+func (p *userLevelPayload) Bind() {
+	p.PayloadBase.BindField(&p.ID, user.ID)
+	orm.BindFieldIfc(&p.Level, levelCase, &p.PayloadBase)
+}
+
+func Test_Case_Select(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{}).MustBuild()
+	cli := getClient(m)
+	m.MockDB.ExpectQuery("SELECT `id`, CASE WHEN `user`.`id` < ? THEN ? WHEN `user`.`id` < ? THEN ? ELSE ? END AS `level` FROM `user` order by `level`").
+		WithArgs(10, "low", 100, "mid", "high").
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "level"}).AddRow(10, "mid"),
+		)
+	var payload []*userLevelPayload
+	err := cli.Table(user).Select().
+		OrderBy(levelCase.Asc()).
+		FindPayload(ctx, &payload)
+	assert.NoError(t, err)
+	assert.Len(t, payload, 1)
+	assert.Equal(t, "mid", payload[0].Level)
+}
+
+func Test_Case_Order(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{Dialect: orm.Postgres}).MustBuild()
+	cli := getClient(m)
+	m.MockDB.ExpectQuery(`SELECT "id", "name" FROM "user" WHERE "user"."team_id" = $1 order by CASE WHEN "user"."name" = $2 THEN $3 ELSE "user"."id" END DESC, "user"."id"`).
+		WithArgs(1, "archever", 0).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "name"}).AddRow(10, "archever"),
+		)
+	var payload []*userPayload
+	priority := orm.Case().When(user.Name.Eq("archever"), 0).Else(user.ID)
+	err := cli.Table(user).Select().
+		Where(user.TeamID.Eq(1)).
+		OrderBy(priority.Desc(true), user.ID.Asc()).
+		FindPayload(ctx, &payload)
+	assert.NoError(t, err)
+}
+
+func Test_Case_Update(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{}).MustBuild()
+	cli := getClient(m)
+	m.MockDB.ExpectExec("UPDATE `user` SET `user`.`team_id` = CASE WHEN `user`.`name` LIKE ? THEN ? END WHERE `user`.`id` > ?").
+		WithArgs("a%", 2, 10).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	cnt, err := cli.Table(user).
+		Update(user.TeamID.SetExpr(orm.Case().When(user.Name.Like("a%"), 2))).
+		Where(user.ID.Gt(10)).
+		Do(ctx)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 3, cnt)
+}
+
+var sizeCase = orm.Case().When(user.ID.Gt(1), "big").Else("small")
+
+type userSizePayload struct {
+	orm.PayloadBase
+	Size string
+	Cnt  int64
+}
+
+func (p *userSizePayload) Bind() {
+	orm.BindFieldIfc(&p.Size, sizeCase, &p.PayloadBase)
+	orm.BindFieldIfc(&p.Cnt, orm.As(orm.Count(nil), "cnt"), &p.PayloadBase)
+}
+
+func Test_Case_GroupBy(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{}).MustBuild()
+	cli := getClient(m)
+	m.MockDB.ExpectQuery("SELECT CASE WHEN `user`.`id` > ? THEN ? ELSE ? END, COUNT(*) AS `cnt` FROM `user` GROUP BY CASE WHEN `user`.`id` > ? THEN ? ELSE ? END").
+		WithArgs(1, "big", "small", 1, "big", "small").
+		WillReturnRows(
+			sqlmock.NewRows([]string{"size", "cnt"}).AddRow("big", 2).AddRow("small", 1),
+		)
+	var payload []*userSizePayload
+	err := cli.Table(user).Select().GroupBy(sizeCase).FindPayload(ctx, &payload)
+	assert.NoError(t, err)
+	assert.Len(t, payload, 2)
+	assert.EqualValues(t, 2, payload[0].Cnt)
+}
+
+func Test_Case_Empty(t *testing.T) {
+	sqlRaw, args := orm.Case().Expr(orm.MySQL)
+	assert.Equal(t, "NULL", sqlRaw)
+	assert.Empty(t, args)
+	sqlRaw, args = orm.Case().Else("none").Expr(orm.MySQL)
+	assert.Equal(t, "?", sqlRaw)
+	assert.Equal(t, []any{"none"}, args)
+}
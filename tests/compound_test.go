@@ -0,0 +1,92 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/archever/orm"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Compound_Union(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{}).MustBuild()
+	cli := getClient(m)
+	{
+		m.MockDB.ExpectQuery("SELECT `id`, `name` FROM `user` WHERE `user`.`team_id` = ? UNION ALL SELECT `id`, `name` FROM `team` WHERE `team`.`id` > ? order by `id` DESC LIMIT ?").
+			WithArgs(1, 2, 10).
+			WillReturnRows(
+				sqlmock.NewRows([]string{"id", "name"}).AddRow(10, "archever").AddRow(3, "team3"),
+			)
+		var payload []*userPayload
+		err := orm.UnionAll(
+			cli.Table(user).Select().Where(user.TeamID.Eq(1)),
+			cli.Table(team).Select().Where(team.ID.Gt(2)),
+		).OrderBy(user.ID.Desc(true)).Limit(10).FindPayload(ctx, &payload)
+		assert.NoError(t, err)
+		assert.Len(t, payload, 2)
+		assert.EqualValues(t, "team3", payload[1].Name)
+	}
+	{
+		m.MockDB.ExpectQuery("SELECT `id`, `name` FROM `user` UNION (SELECT `id`, `name` FROM `team` LIMIT ?) LIMIT ?").
+			WithArgs(5, 1).
+			WillReturnRows(
+				sqlmock.NewRows([]string{"id", "name"}).AddRow(10, "archever"),
+			)
+		var payload userPayload
+		err := orm.Union(
+			cli.Table(user).Select(),
+			cli.Table(team).Select().Limit(5),
+		).TakePayload(ctx, &payload)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 10, payload.ID)
+	}
+}
+
+func Test_Compound_SubQuery(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{}).MustBuild()
+	cli := getClient(m)
+	m.MockDB.ExpectQuery("SELECT `id`, `name` FROM `user` WHERE `user`.`team_id` IN (SELECT `id` FROM `team` WHERE `team`.`name` = ? EXCEPT SELECT `team_id` FROM `user` WHERE `user`.`name` = ?)").
+		WithArgs("team1", "archever").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(10, "archever"))
+	subQuery := orm.Except(
+		cli.Table(team).Select(team.ID).Where(team.Name.Eq("team1")),
+		cli.Table(user).Select(user.TeamID).Where(user.Name.Eq("archever")),
+	).SubQuery()
+	var payload []*userPayload
+	err := cli.Table(user).Select().Where(user.TeamID.InQuery(subQuery)).FindPayload(ctx, &payload)
+	assert.NoError(t, err)
+
+	_, err = orm.Intersect(cli.Table(team).Select(team.ID)).Do(ctx)
+	assert.Error(t, err)
+}
+
+func Test_Compound_OrderByArgs(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{}).MustBuild()
+	cli := getClient(m)
+	m.MockDB.ExpectQuery("SELECT `id`, `name` FROM `user` UNION SELECT `id`, `name` FROM `team` order by CASE WHEN `name` = ? THEN ? ELSE ? END, `id`").
+		WithArgs("vip", 0, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(10, "vip"))
+	var payload []*userPayload
+	err := orm.Union(
+		cli.Table(user).Select(),
+		cli.Table(team).Select(),
+	).OrderBy(orm.Case().When(orm.Raw("`name` = ?", "vip").Cond(), 0).Else(1).Asc(), user.ID.Asc()).FindPayload(ctx, &payload)
+	assert.NoError(t, err)
+	assert.Len(t, payload, 1)
+}
+
+func Test_Compound_SQLiteParens(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{Dialect: orm.SQLite}).MustBuild()
+	cli := getClient(m)
+	var payload []*userPayload
+	err := orm.Union(
+		cli.Table(user).Select(),
+		cli.Table(team).Select().Limit(5),
+	).FindPayload(ctx, &payload)
+	assert.ErrorIs(t, err, orm.ErrNotSupported)
+}
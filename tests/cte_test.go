@@ -0,0 +1,74 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/archever/orm"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	tree       = orm.NewTable("tree")
+	treeID     = orm.NewField[int64]("id", tree)
+	treeTeamID = orm.NewField[int64]("team_id", tree)
+)
+
+type treePayload struct {
+	orm.PayloadBase
+	ID       int64
+	TeamName string
+}
+
+func (p *treePayload) Bind() {
+	p.PayloadBase.BindField(&p.ID, treeID)
+	p.PayloadBase.BindField(&p.TeamName, team.Name)
+}
+
+type treeIDPayload struct {
+	orm.PayloadBase
+	ID int64
+}
+
+func (p *treeIDPayload) Bind() {
+	p.PayloadBase.BindField(&p.ID, treeID)
+}
+
+func Test_CTE_With(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{}).MustBuild()
+	cli := getClient(m)
+	m.MockDB.ExpectQuery("WITH `tree` AS (SELECT `id`, `team_id` FROM `user` WHERE `user`.`name` = ?) SELECT `tree`.`id`, `team`.`name` FROM `tree` JOIN `team` ON `tree`.`team_id` = `team`.`id`").
+		WithArgs("archever").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(10, "team1"))
+	var payload []*treePayload
+	err := cli.Table(tree).Select().
+		With("tree", cli.Table(user).Select(user.ID, user.TeamID).Where(user.Name.Eq("archever"))).
+		Join(team, treeTeamID.EqCol(team.ID)).
+		FindPayload(ctx, &payload)
+	assert.NoError(t, err)
+	assert.Len(t, payload, 1)
+	assert.EqualValues(t, "team1", payload[0].TeamName)
+}
+
+func Test_CTE_WithRecursive(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{Dialect: orm.Postgres}).MustBuild()
+	cli := getClient(m)
+	m.MockDB.ExpectQuery(`WITH RECURSIVE "tree" AS (SELECT "id", "team_id" FROM "user" WHERE "user"."id" = $1 UNION ALL SELECT "user"."id", "user"."team_id" FROM "user" JOIN "tree" ON "user"."team_id" = "tree"."id") SELECT "id" FROM "tree" LIMIT $2`).
+		WithArgs(1, 100).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+	query := orm.UnionAll(
+		cli.Table(user).Select(user.ID, user.TeamID).Where(user.ID.Eq(1)),
+		cli.Table(user).Select(user.ID, user.TeamID).Join(tree, user.TeamID.EqCol(*treeID)),
+	)
+	var payload []*treeIDPayload
+	err := cli.Table(tree).Select().
+		WithRecursive("tree", query).
+		Limit(100).
+		FindPayload(ctx, &payload)
+	assert.NoError(t, err)
+	assert.Len(t, payload, 2)
+	assert.EqualValues(t, 2, payload[1].ID)
+}
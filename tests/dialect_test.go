@@ -0,0 +1,114 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/archever/orm"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Dialect_Get(t *testing.T) {
+	assert.Equal(t, orm.MySQL, orm.GetDialect("mysql"))
+	assert.Equal(t, orm.Postgres, orm.GetDialect("postgres"))
+	assert.Equal(t, orm.Postgres, orm.GetDialect("pgx"))
+	assert.Equal(t, orm.SQLite, orm.GetDialect("sqlite"))
+	assert.Equal(t, orm.SQLite, orm.GetDialect("sqlite3"))
+	assert.Equal(t, orm.MySQL, orm.GetDialect("unknown"))
+}
+
+func Test_Dialect_MySQLOffset(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{}).MustBuild()
+	cli := getClient(m)
+	m.MockDB.ExpectQuery("SELECT `id`, `name` FROM `user` LIMIT 18446744073709551615 OFFSET ?").
+		WithArgs(5).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "name"}).AddRow(10, "archever"),
+		)
+	var payload []*userPayload
+	err := cli.Table(user).Select().Offset(5).FindPayload(ctx, &payload)
+	assert.NoError(t, err)
+}
+
+func Test_Dialect_PostgresSelect(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{Dialect: orm.Postgres}).MustBuild()
+	cli := getClient(m)
+	{
+		m.MockDB.ExpectQuery(`SELECT "user"."id", "user"."name" FROM "user" JOIN "team" ON "user"."team_id" = "team"."id" WHERE ("user"."id" = $1 AND "user"."name" IN ($2,$3)) LIMIT $4 OFFSET $5`).
+			WithArgs(10, "a", "b", 10, 20).
+			WillReturnRows(
+				sqlmock.NewRows([]string{"id", "name"}).AddRow(10, "archever"),
+			)
+		var payload []*userPayload
+		err := cli.Table(user).Select().
+			Join(team, user.TeamID.EqCol(team.ID)).
+			Where(user.ID.Eq(10), user.Name.In("a", "b")).
+			Page(3, 10).
+			FindPayload(ctx, &payload)
+		assert.NoError(t, err)
+	}
+	{
+		m.MockDB.ExpectQuery(`SELECT "id", "name" FROM "user" WHERE "user"."team_id" IN (SELECT "id" FROM "team" WHERE "team"."id" = $1) OFFSET $2`).
+			WithArgs(10, 5).
+			WillReturnRows(
+				sqlmock.NewRows([]string{"id", "name"}).AddRow(10, "archever"),
+			)
+		var payload []*userPayload
+		subQuery := cli.Table(team).Select(team.ID).Where(team.ID.Eq(10)).SubQuery()
+		err := cli.Table(user).Select().
+			Where(user.TeamID.InQuery(subQuery)).
+			Offset(5).
+			FindPayload(ctx, &payload)
+		assert.NoError(t, err)
+	}
+}
+
+func Test_Dialect_PostgresExec(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{Dialect: orm.Postgres}).MustBuild()
+	cli := getClient(m)
+	m.MockDB.ExpectExec(`UPDATE "user" SET "name" = $1 WHERE "user"."id" = $2`).
+		WithArgs("name1", 10).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	cnt, err := cli.Table(user).Update(user.Name.Eq("name1")).Where(user.ID.Eq(10)).Do(ctx)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, cnt)
+
+	m.MockDB.ExpectExec(`DELETE FROM "user" WHERE "user"."id" = $1`).
+		WithArgs(10).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	cnt, err = cli.Table(user).Delete().Where(user.ID.Eq(10)).Do(ctx)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, cnt)
+}
+
+func Test_Dialect_SQLite(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{Dialect: orm.SQLite}).MustBuild()
+	cli := getClient(m)
+	m.MockDB.ExpectQuery(`SELECT "id", "name" FROM "user" WHERE "user"."id" > ? LIMIT -1 OFFSET ?`).
+		WithArgs(1, 5).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "name"}).AddRow(10, "archever"),
+		)
+	var payload []*userPayload
+	err := cli.Table(user).Select().Where(user.ID.Gt(1)).Offset(5).FindPayload(ctx, &payload)
+	assert.NoError(t, err)
+
+	// auto increment ids are read back with RETURNING
+	m.MockDB.ExpectQuery(`INSERT INTO "user" ("name") VALUES(?),(?) RETURNING "id"`).
+		WithArgs("name1", "name2").
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id"}).AddRow(11).AddRow(12),
+		)
+	payload1 := userPayload{Name: "name1"}
+	payload2 := userPayload{Name: "name2"}
+	cnt, err := cli.Table(user).InsertPayload(&payload1, &payload2).Do(ctx)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, cnt)
+	assert.EqualValues(t, 11, payload1.ID)
+	assert.EqualValues(t, 12, payload2.ID)
+}
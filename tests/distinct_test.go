@@ -0,0 +1,127 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/archever/orm"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Distinct(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{}).MustBuild()
+	cli := getClient(m)
+	m.MockDB.ExpectQuery("SELECT DISTINCT `id`, `name` FROM `team` WHERE `team`.`id` > ? order by `team`.`name`").
+		WithArgs(1).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "name"}).AddRow(2, "team2"),
+		)
+	var payload []*teamPayload
+	err := cli.Table(team).Select().
+		Distinct().
+		Where(team.ID.Gt(1)).
+		OrderBy(team.Name.Asc()).
+		FindPayload(ctx, &payload)
+	assert.NoError(t, err)
+	assert.Len(t, payload, 1)
+	assert.NoError(t, m.MockDB.ExpectationsWereMet())
+}
+
+func Test_Distinct_On(t *testing.T) {
+	ctx := context.Background()
+	{
+		m := (&mockInc{Dialect: orm.Postgres}).MustBuild()
+		cli := getClient(m)
+		m.MockDB.ExpectQuery(`SELECT DISTINCT ON ("team_id") "id", "name" FROM "user" order by "user"."team_id", "user"."id" DESC`).
+			WillReturnRows(
+				sqlmock.NewRows([]string{"id", "name"}).AddRow(2, "archever2"),
+			)
+		var payload []*userPayload
+		err := cli.Table(user).Select().
+			DistinctOn(user.TeamID).
+			OrderBy(user.TeamID.Asc(), user.ID.Desc(true)).
+			FindPayload(ctx, &payload)
+		assert.NoError(t, err)
+		assert.NoError(t, m.MockDB.ExpectationsWereMet())
+	}
+	{
+		m := (&mockInc{Dialect: orm.Postgres}).MustBuild()
+		cli := getClient(m)
+		m.MockDB.ExpectQuery(`SELECT DISTINCT ON (CASE WHEN "user"."team_id" = $1 THEN $2 ELSE $3 END) "id", "name" FROM "user" WHERE "user"."id" > $4`).
+			WithArgs(1, "first", "other", 0).
+			WillReturnRows(
+				sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "archever"),
+			)
+		var payload []*userPayload
+		err := cli.Table(user).Select().
+			DistinctOn(orm.Case().When(user.TeamID.Eq(1), "first").Else("other")).
+			Where(user.ID.Gt(0)).
+			FindPayload(ctx, &payload)
+		assert.NoError(t, err)
+		assert.NoError(t, m.MockDB.ExpectationsWereMet())
+	}
+	{
+		m := (&mockInc{}).MustBuild()
+		cli := getClient(m)
+		var payload []*userPayload
+		err := cli.Table(user).Select().
+			DistinctOn(user.TeamID).
+			FindPayload(ctx, &payload)
+		assert.ErrorIs(t, err, orm.ErrNotSupported)
+	}
+}
+
+func Test_Distinct_Count(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{}).MustBuild()
+	cli := getClient(m)
+	m.MockDB.ExpectQuery("SELECT COUNT(*) FROM `user` WHERE `user`.`team_id` = ?").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"cnt"}).AddRow(3))
+	m.MockDB.ExpectQuery("SELECT COUNT(DISTINCT `team_id`) FROM `user`").
+		WillReturnRows(sqlmock.NewRows([]string{"cnt"}).AddRow(2))
+	m.MockDB.ExpectQuery("SELECT COUNT(*) FROM (SELECT DISTINCT `team_id` AS `c1`, `name` AS `c2` FROM `user` WHERE `user`.`id` > ?) AS `t`").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"cnt"}).AddRow(4))
+	m.MockDB.ExpectQuery("SELECT COUNT(*) FROM (SELECT `team_id` AS `c1` FROM `user` GROUP BY `team_id`) AS `t`").
+		WillReturnRows(sqlmock.NewRows([]string{"cnt"}).AddRow(2))
+	m.MockDB.ExpectQuery("SELECT COUNT(*) FROM (SELECT DISTINCT `user`.`id` AS `c1`, `team`.`id` AS `c2` FROM `user` JOIN `team` ON `user`.`team_id` = `team`.`id`) AS `t`").
+		WillReturnRows(sqlmock.NewRows([]string{"cnt"}).AddRow(3))
+
+	cnt, err := cli.Table(user).Select().
+		Where(user.TeamID.Eq(1)).
+		OrderBy(user.ID.Asc()).
+		Limit(10).
+		Count(ctx)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 3, cnt)
+
+	cnt, err = cli.Table(user).Select(user.TeamID).Distinct().Count(ctx)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, cnt)
+
+	cnt, err = cli.Table(user).Select(user.TeamID, user.Name).
+		Distinct().
+		Where(user.ID.Gt(1)).
+		Count(ctx)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 4, cnt)
+
+	cnt, err = cli.Table(user).Select().GroupBy(user.TeamID).Count(ctx)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, cnt)
+
+	// the same column name of the joined tables
+	cnt, err = cli.Table(user).Select(user.ID, team.ID).
+		Join(team, user.TeamID.EqCol(team.ID)).
+		Distinct().
+		Count(ctx)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 3, cnt)
+
+	_, err = cli.Table(user).Select().Distinct().Count(ctx)
+	assert.Error(t, err)
+	assert.NoError(t, m.MockDB.ExpectationsWereMet())
+}
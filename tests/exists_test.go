@@ -0,0 +1,56 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/archever/orm"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Exists(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{}).MustBuild()
+	cli := getClient(m)
+	{
+		m.MockDB.ExpectQuery("SELECT `id`, `name` FROM `team` WHERE EXISTS (SELECT 1 FROM `user` WHERE (`user`.`team_id` = `team`.`id` AND `user`.`name` = ?))").
+			WithArgs("archever").
+			WillReturnRows(
+				sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "team1"),
+			)
+		var payload []*teamPayload
+		err := cli.Table(team).Select().
+			Where(orm.Exists(
+				cli.Table(user).Select().Where(user.TeamID.EqCol(team.ID), user.Name.Eq("archever")),
+			)).
+			FindPayload(ctx, &payload)
+		assert.NoError(t, err)
+		assert.Len(t, payload, 1)
+	}
+	{
+		m.MockDB.ExpectQuery("SELECT `id`, `name` FROM `team` WHERE (`team`.`id` > ? AND NOT EXISTS (SELECT `id` FROM `user` WHERE `user`.`team_id` = `team`.`id`))").
+			WithArgs(1).
+			WillReturnRows(
+				sqlmock.NewRows([]string{"id", "name"}).AddRow(2, "team2"),
+			)
+		var payload []*teamPayload
+		err := cli.Table(team).Select().
+			Where(team.ID.Gt(1), orm.NotExists(
+				cli.Table(user).Select(user.ID).Where(user.TeamID.EqCol(team.ID)),
+			)).
+			FindPayload(ctx, &payload)
+		assert.NoError(t, err)
+	}
+}
+
+func Test_Exists_KeepsQuery(t *testing.T) {
+	m := (&mockInc{}).MustBuild()
+	cli := getClient(m)
+	query := cli.Table(user).Select().Where(user.TeamID.EqCol(team.ID))
+	before, _ := query.SubQuery().Expr(orm.MySQL)
+	sqlRaw, _ := cli.Table(team).Select(team.ID).Where(orm.Exists(query)).SubQuery().Expr(orm.MySQL)
+	assert.Equal(t, "SELECT `id` FROM `team` WHERE EXISTS (SELECT 1 FROM `user` WHERE `user`.`team_id` = `team`.`id`)", sqlRaw)
+	after, _ := query.SubQuery().Expr(orm.MySQL)
+	assert.Equal(t, before, after)
+}
@@ -0,0 +1,99 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/archever/orm"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	rankedUser     = orm.NewTable("ranked")
+	rankedUserID   = orm.FieldOf(rankedUser, user.ID)
+	rankedUserName = orm.FieldOf(rankedUser, user.Name)
+	rankedUserRn   = orm.NewField[int64]("rn", rankedUser)
+)
+
+type rankedUserPayload struct {
+	orm.PayloadBase
+	ID   int64
+	Name string
+}
+
+func (p *rankedUserPayload) Bind() {
+	p.PayloadBase.BindField(&p.ID, rankedUserID)
+	p.PayloadBase.BindField(&p.Name, rankedUserName)
+}
+
+func Test_From_TopN(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{Dialect: orm.Postgres}).MustBuild()
+	cli := getClient(m)
+	m.MockDB.ExpectQuery(`SELECT "id", "name" FROM (SELECT "id", "name", ROW_NUMBER() OVER (PARTITION BY "team_id" ORDER BY "id" DESC) AS "rn" FROM "user" WHERE "user"."name" <> $1) AS "ranked" WHERE "ranked"."rn" <= $2 order by "ranked"."id"`).
+		WithArgs("", 2).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "name"}).AddRow(3, "name").AddRow(2, "archever2"),
+		)
+	ranked := cli.Table(user).Select(
+		user.ID,
+		user.Name,
+		orm.As(orm.RowNumber().Over(orm.PartitionBy(user.TeamID).OrderBy(user.ID.Desc(true))), "rn"),
+	).Where(user.Name.NotEq(""))
+	var payload []*rankedUserPayload
+	err := cli.From(ranked, "ranked").Select().
+		Where(rankedUserRn.Lte(2)).
+		OrderBy(rankedUserID.Asc()).
+		FindPayload(ctx, &payload)
+	assert.NoError(t, err)
+	assert.Len(t, payload, 2)
+	assert.EqualValues(t, 3, payload[0].ID)
+	assert.NoError(t, m.MockDB.ExpectationsWereMet())
+}
+
+type teamStatPagePayload struct {
+	orm.PayloadBase
+	TeamID int64
+	Cnt    int64
+}
+
+func (p *teamStatPagePayload) Bind() {
+	p.PayloadBase.BindField(&p.TeamID, teamStatTeamID)
+	p.PayloadBase.BindField(&p.Cnt, teamStatCnt)
+}
+
+func Test_From_Page(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{}).MustBuild()
+	cli := getClient(m)
+	m.MockDB.ExpectQuery("SELECT `team_id`, `cnt` FROM (SELECT `team_id`, COUNT(*) AS `cnt` FROM `user` GROUP BY `team_id`) AS `stat` WHERE `stat`.`cnt` > ? order by `stat`.`cnt` DESC LIMIT ? OFFSET ?").
+		WithArgs(1, 10, 20).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"team_id", "cnt"}).AddRow(1, 3),
+		)
+	stat := cli.Table(user).
+		Select(user.TeamID, orm.As(orm.Count(nil), "cnt")).
+		GroupBy(user.TeamID)
+	var payload []*teamStatPagePayload
+	err := cli.From(stat, "stat").Select().
+		Where(teamStatCnt.Gt(1)).
+		OrderBy(teamStatCnt.Desc(true)).
+		Limit(10).Offset(20).
+		FindPayload(ctx, &payload)
+	assert.NoError(t, err)
+	assert.Len(t, payload, 1)
+	assert.EqualValues(t, 3, payload[0].Cnt)
+	assert.NoError(t, m.MockDB.ExpectationsWereMet())
+}
+
+func Test_From_Error(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{}).MustBuild()
+	cli := getClient(m)
+	query := cli.Table(user).Select(user.ID)
+	query.SkipLocked()
+	var payload []*teamStatPagePayload
+	err := cli.From(query, "sub").Select().FindPayload(ctx, &payload)
+	assert.EqualError(t, err, "SKIP LOCKED requires ForUpdate or ForShare")
+}
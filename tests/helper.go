@@ -8,7 +8,8 @@ import (
 )
 
 type mockInc struct {
-	MockDB sqlmock.Sqlmock
+	MockDB  sqlmock.Sqlmock
+	Dialect orm.Dialect
 
 	DB *sql.DB
 }
@@ -35,6 +36,7 @@ func (m *mockInc) MustBuild() *mockInc {
 
 func getClient(m *mockInc) *orm.Client {
 	return &orm.Client{
-		DB: orm.NewDefaultExecutor(m.DB),
+		DB:      orm.NewDefaultExecutor(m.DB),
+		Dialect: m.Dialect,
 	}
 }
@@ -0,0 +1,76 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/archever/orm"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Hint_MySQL(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{}).MustBuild()
+	cli := getClient(m)
+	{
+		m.MockDB.ExpectQuery("SELECT `id`, `name` FROM `user` USE INDEX (`idx_team`, `idx_name`) WHERE `user`.`team_id` = ?").
+			WithArgs(1).
+			WillReturnRows(
+				sqlmock.NewRows([]string{"id", "name"}).AddRow(10, "archever"),
+			)
+		var payload []*userPayload
+		err := cli.Table(user).Select().
+			UseIndex(user, "idx_team", "idx_name").
+			Where(user.TeamID.Eq(1)).
+			FindPayload(ctx, &payload)
+		assert.NoError(t, err)
+	}
+	{
+		m.MockDB.ExpectQuery("SELECT `user`.`id`, `user`.`name` FROM `user` FORCE INDEX (`PRIMARY`) JOIN `team` IGNORE INDEX (`uk_name`) ON `user`.`team_id` = `team`.`id`").
+			WillReturnRows(
+				sqlmock.NewRows([]string{"id", "name"}).AddRow(10, "archever"),
+			)
+		var payload []*userPayload
+		err := cli.Table(user).Select().
+			Join(team, user.TeamID.EqCol(team.ID)).
+			IgnoreIndex(team, "uk_name").
+			ForceIndex(user, "PRIMARY").
+			FindPayload(ctx, &payload)
+		assert.NoError(t, err)
+	}
+	{
+		// the hinted table is not in the select
+		var payload []*userPayload
+		err := cli.Table(user).Select().
+			UseIndex(team, "uk_name").
+			FindPayload(ctx, &payload)
+		assert.Error(t, err)
+	}
+	assert.NoError(t, m.MockDB.ExpectationsWereMet())
+}
+
+func Test_Hint_Postgres(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{Dialect: orm.Postgres}).MustBuild()
+	cli := getClient(m)
+	m.MockDB.ExpectQuery(`SELECT "id", "name" FROM "user" WHERE "user"."team_id" = $1`).
+		WithArgs(1).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "name"}).AddRow(10, "archever"),
+		)
+	var payload []*userPayload
+	err := cli.Table(user).Select().
+		UseIndex(user, "idx_team").
+		Where(user.TeamID.Eq(1)).
+		FindPayload(ctx, &payload)
+	assert.NoError(t, err)
+
+	cli.Strict = true
+	err = cli.Table(user).Select().
+		UseIndex(user, "idx_team").
+		Where(user.TeamID.Eq(1)).
+		FindPayload(ctx, &payload)
+	assert.ErrorIs(t, err, orm.ErrNotSupported)
+	assert.NoError(t, m.MockDB.ExpectationsWereMet())
+}
@@ -2,9 +2,11 @@ package tests
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/archever/orm"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -23,3 +25,198 @@ func Test_Insert_Simple(t *testing.T) {
 	assert.EqualValues(t, 1, payload1.ID)
 	assert.EqualValues(t, 2, payload2.ID)
 }
+
+type userTeamPayload struct {
+	orm.PayloadBase
+	Name   string
+	TeamID int64
+}
+
+func (p *userTeamPayload) Bind() {
+	p.PayloadBase.BindField(&p.Name, user.Name)
+	p.PayloadBase.BindField(&p.TeamID, user.TeamID)
+}
+
+func Test_Insert_Upsert(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{}).MustBuild()
+	cli := getClient(m)
+	{
+		m.MockDB.ExpectExec("INSERT INTO `user` (`name`) VALUES(?) ON DUPLICATE KEY UPDATE `name` = VALUES(`name`)").
+			WithArgs("name1").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		payload := userPayload{Name: "name1"}
+		_, err := cli.Table(user).InsertPayload(&payload).OnConflict(user.ID).DoUpdate().Do(ctx)
+		assert.NoError(t, err)
+	}
+	{
+		m.MockDB.ExpectExec("INSERT INTO `user` (`name`) VALUES(?) ON DUPLICATE KEY UPDATE `id` = `id`").
+			WithArgs("name1").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		payload := userPayload{Name: "name1"}
+		_, err := cli.Table(user).InsertPayload(&payload).OnConflict().DoNothing().Do(ctx)
+		assert.NoError(t, err)
+	}
+	{
+		m.MockDB.ExpectExec("INSERT INTO `user` (`name`,`team_id`) VALUES(?,?) ON DUPLICATE KEY UPDATE `team_id` = VALUES(`team_id`)").
+			WithArgs("name1", 2).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		payload := userTeamPayload{Name: "name1", TeamID: 2}
+		_, err := cli.Table(user).InsertPayload(&payload).OnConflict().DoUpdateFields(user.TeamID).Do(ctx)
+		assert.NoError(t, err)
+	}
+	{
+		m.MockDB.ExpectExec("INSERT INTO `user` (`name`) VALUES(?) ON DUPLICATE KEY UPDATE `user`.`team_id` = ?").
+			WithArgs("name1", 2).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		payload := userPayload{Name: "name1"}
+		_, err := cli.Table(user).InsertPayload(&payload).
+			OnConflict(user.Name).
+			DoUpdate(user.TeamID.Eq(2)).
+			Do(ctx)
+		assert.NoError(t, err)
+	}
+}
+
+func Test_Insert_UpsertPostgres(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{Dialect: orm.Postgres}).MustBuild()
+	cli := getClient(m)
+	{
+		m.MockDB.ExpectQuery(`INSERT INTO "user" ("name") VALUES($1),($2) ON CONFLICT ("name") DO UPDATE SET "team_id" = $3 RETURNING "id", "name"`).
+			WithArgs("name1", "name2", 2).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "name1").AddRow(2, "name2"))
+		payload1 := userPayload{Name: "name1"}
+		payload2 := userPayload{Name: "name2"}
+		_, err := cli.Table(user).InsertPayload(&payload1, &payload2).
+			OnConflict(user.Name).
+			DoUpdate(user.TeamID.Eq(2)).
+			Do(ctx)
+		assert.NoError(t, err)
+	}
+	{
+		m.MockDB.ExpectExec(`INSERT INTO "user" ("name","team_id") VALUES($1,$2) ON CONFLICT ("name") DO UPDATE SET "team_id" = EXCLUDED."team_id"`).
+			WithArgs("name1", 2).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		payload := userTeamPayload{Name: "name1", TeamID: 2}
+		_, err := cli.Table(user).InsertPayload(&payload).OnConflict(user.Name).DoUpdate().Do(ctx)
+		assert.NoError(t, err)
+	}
+	{
+		m.MockDB.ExpectQuery(`INSERT INTO "user" ("name") VALUES($1) ON CONFLICT DO NOTHING RETURNING "id"`).
+			WithArgs("name1").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		payload := userPayload{Name: "name1"}
+		_, err := cli.Table(user).InsertPayload(&payload).OnConflict().DoNothing().Do(ctx)
+		assert.NoError(t, err)
+	}
+	{
+		// postgres needs the conflict target to update
+		payload := userTeamPayload{Name: "name1", TeamID: 2}
+		_, err := cli.Table(user).InsertPayload(&payload).OnConflict().DoUpdate().Do(ctx)
+		assert.ErrorIs(t, err, orm.ErrNotSupported)
+		_, err = cli.Table(user).InsertPayload(&payload).OnConflict().DoUpdateFields(user.TeamID).Do(ctx)
+		assert.ErrorIs(t, err, orm.ErrNotSupported)
+	}
+	assert.NoError(t, m.MockDB.ExpectationsWereMet())
+}
+
+func Test_Insert_UpsertSkippedRow(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{Dialect: orm.Postgres}).MustBuild()
+	cli := getClient(m)
+	{
+		// t1 exists, only the row of t3 is returned
+		m.MockDB.ExpectQuery(`INSERT INTO "team" ("name") VALUES($1),($2) ON CONFLICT ("name") DO NOTHING RETURNING "id", "name"`).
+			WithArgs("t1", "t3").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(4, "t3"))
+		payload1 := teamPayload{Name: "t1"}
+		payload3 := teamPayload{Name: "t3"}
+		cnt, err := cli.Table(team).InsertPayload(&payload1, &payload3).OnConflict(team.Name).DoNothing().Do(ctx)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 1, cnt)
+		assert.EqualValues(t, 0, payload1.ID)
+		assert.EqualValues(t, 4, payload3.ID)
+	}
+	{
+		// the rows can't be matched to the payloads without the target
+		payload1 := teamPayload{Name: "t1"}
+		payload3 := teamPayload{Name: "t3"}
+		_, err := cli.Table(team).InsertPayload(&payload1, &payload3).OnConflict().DoNothing().Do(ctx)
+		assert.Error(t, err)
+	}
+	assert.NoError(t, m.MockDB.ExpectationsWereMet())
+}
+
+func Test_Insert_FromQuery(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{}).MustBuild()
+	cli := getClient(m)
+	{
+		m.MockDB.ExpectExec("INSERT INTO `user` (`name`,`team_id`) SELECT `name`, `id` FROM `team` WHERE `team`.`id` > ?").
+			WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(3, 2))
+		query := cli.Table(team).Select(team.Name, team.ID).Where(team.ID.Gt(1))
+		cnt, err := cli.Table(user).InsertFrom([]orm.FieldIfc{user.Name, user.TeamID}, query).Do(ctx)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 2, cnt)
+	}
+	{
+		query := cli.Table(team).Select(team.Name)
+		_, err := cli.Table(user).InsertFrom([]orm.FieldIfc{user.Name, user.TeamID}, query).Do(ctx)
+		assert.Error(t, err)
+	}
+	assert.NoError(t, m.MockDB.ExpectationsWereMet())
+}
+
+// noInsertIDResult is a driver result without LastInsertId, like lib/pq's
+type noInsertIDResult int64
+
+func (r noInsertIDResult) LastInsertId() (int64, error) {
+	return 0, errors.New("LastInsertId is not supported by this driver")
+}
+
+func (r noInsertIDResult) RowsAffected() (int64, error) {
+	return int64(r), nil
+}
+
+type userNamePayload struct {
+	orm.PayloadBase
+	Name string
+}
+
+func (p *userNamePayload) Bind() {
+	p.PayloadBase.BindField(&p.Name, user.Name)
+}
+
+func Test_Insert_NoLastInsertID(t *testing.T) {
+	ctx := context.Background()
+	{
+		m := (&mockInc{Dialect: orm.Postgres}).MustBuild()
+		cli := getClient(m)
+		m.MockDB.ExpectQuery(`INSERT INTO "user" ("name") VALUES($1),($2) RETURNING "id"`).
+			WithArgs("name1", "name2").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3).AddRow(4))
+		payload1 := userPayload{Name: "name1"}
+		payload2 := userPayload{Name: "name2"}
+		cnt, err := cli.Table(user).InsertPayload(&payload1, &payload2).Do(ctx)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 2, cnt)
+		assert.EqualValues(t, 3, payload1.ID)
+		assert.EqualValues(t, 4, payload2.ID)
+		assert.NoError(t, m.MockDB.ExpectationsWereMet())
+	}
+	{
+		// no auto increment field bound, LastInsertId is never asked for
+		m := (&mockInc{}).MustBuild()
+		cli := getClient(m)
+		m.MockDB.ExpectExec("INSERT INTO `user` (`name`) VALUES(?)").
+			WithArgs("name1").
+			WillReturnResult(noInsertIDResult(1))
+		payload := userNamePayload{Name: "name1"}
+		cnt, err := cli.Table(user).InsertPayload(&payload).Do(ctx)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 1, cnt)
+		assert.NoError(t, m.MockDB.ExpectationsWereMet())
+	}
+}
@@ -0,0 +1,114 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/archever/orm"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Join_Full(t *testing.T) {
+	ctx := context.Background()
+	{
+		m := (&mockInc{Dialect: orm.Postgres}).MustBuild()
+		cli := getClient(m)
+		m.MockDB.ExpectQuery(`SELECT "user"."id", "user"."name" FROM "user" FULL JOIN "team" ON ("user"."team_id" = "team"."id" AND "team"."name" <> $1)`).
+			WithArgs("").
+			WillReturnRows(
+				sqlmock.NewRows([]string{"id", "name"}).AddRow(10, "archever"),
+			)
+		m.MockDB.ExpectQuery(`SELECT "user"."id", "user"."name" FROM "user" FULL JOIN "team" ON "user"."team_id" = "team"."id"`).
+			WillReturnRows(
+				sqlmock.NewRows([]string{"id", "name"}).AddRow(10, "archever"),
+			)
+		var payload []*userPayload
+		err := cli.Table(user).Select().
+			FullJoin(team, user.TeamID.EqCol(team.ID), team.Name.NotEq("")).
+			FindPayload(ctx, &payload)
+		assert.NoError(t, err)
+		err = cli.Table(user).Select().
+			OuterJoin(team, user.TeamID.EqCol(team.ID)).
+			FindPayload(ctx, &payload)
+		assert.NoError(t, err)
+		assert.NoError(t, m.MockDB.ExpectationsWereMet())
+	}
+	{
+		m := (&mockInc{}).MustBuild()
+		cli := getClient(m)
+		var payload []*userPayload
+		err := cli.Table(user).Select().
+			FullJoin(team, user.TeamID.EqCol(team.ID)).
+			FindPayload(ctx, &payload)
+		assert.ErrorIs(t, err, orm.ErrNotSupported)
+	}
+}
+
+func Test_Join_CrossUsing(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{}).MustBuild()
+	cli := getClient(m)
+	m.MockDB.ExpectQuery("SELECT `user`.`id`, `user`.`name` FROM `user` CROSS JOIN `team` WHERE `team`.`id` = ?").
+		WithArgs(1).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "name"}).AddRow(10, "archever"),
+		)
+	m.MockDB.ExpectQuery("SELECT `user`.`id`, `user`.`name` FROM `user` LEFT JOIN `team` ON `user`.`team_id` = `team`.`id` JOIN `setting` USING (`id`)").
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "name"}).AddRow(10, "archever"),
+		)
+	var payload []*userPayload
+	err := cli.Table(user).Select().
+		CrossJoin(team).
+		Where(team.ID.Eq(1)).
+		FindPayload(ctx, &payload)
+	assert.NoError(t, err)
+	err = cli.Table(user).Select().
+		LeftJoin(team, user.TeamID.EqCol(team.ID)).
+		JoinUsing(setting, user.ID).
+		FindPayload(ctx, &payload)
+	assert.NoError(t, err)
+	assert.NoError(t, m.MockDB.ExpectationsWereMet())
+}
+
+var (
+	teamStat       = orm.NewTable("stat")
+	teamStatTeamID = orm.FieldOf(teamStat, user.TeamID)
+	teamStatCnt    = orm.NewField[int64]("cnt", teamStat)
+)
+
+type teamUserCntPayload struct {
+	orm.PayloadBase
+	Name string
+	Cnt  int64
+}
+
+func (p *teamUserCntPayload) Bind() {
+	p.PayloadBase.BindField(&p.Name, team.Name)
+	p.PayloadBase.BindField(&p.Cnt, teamStatCnt)
+}
+
+func Test_Join_Sub(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{Dialect: orm.Postgres}).MustBuild()
+	cli := getClient(m)
+	m.MockDB.ExpectQuery(`SELECT "team"."name", "stat"."cnt" FROM "team" JOIN (SELECT "team_id", COUNT(*) AS "cnt" FROM "user" WHERE "user"."id" > $1 GROUP BY "team_id") AS "stat" ON "team"."id" = "stat"."team_id" WHERE "stat"."cnt" > $2`).
+		WithArgs(10, 1).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"name", "cnt"}).AddRow("team1", 2),
+		)
+	stat := cli.Table(user).
+		Select(user.TeamID, orm.As(orm.Count(nil), "cnt")).
+		Where(user.ID.Gt(10)).
+		GroupBy(user.TeamID)
+	var payload []*teamUserCntPayload
+	err := cli.Table(team).Select().
+		JoinSub(stat, "stat", team.ID.EqCol(teamStatTeamID)).
+		Where(teamStatCnt.Gt(1)).
+		FindPayload(ctx, &payload)
+	assert.NoError(t, err)
+	assert.Len(t, payload, 1)
+	assert.EqualValues(t, 2, payload[0].Cnt)
+	assert.NoError(t, m.MockDB.ExpectationsWereMet())
+}
@@ -0,0 +1,94 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/archever/orm"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_JoinWrite_MySQL(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{}).MustBuild()
+	cli := getClient(m)
+	m.MockDB.ExpectExec("UPDATE `user` JOIN `team` ON `user`.`team_id` = `team`.`id` SET `user`.`name` = `team`.`name` WHERE `team`.`name` = ?").
+		WithArgs("team1").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	m.MockDB.ExpectExec("DELETE `user` FROM `user` LEFT JOIN `team` ON `user`.`team_id` = `team`.`id` WHERE `team`.`id` IS NULL").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	cnt, err := cli.Table(user).
+		Update(user.Name.SetCol(team.Name)).
+		Join(team, user.TeamID.EqCol(team.ID)).
+		Where(team.Name.Eq("team1")).
+		Do(ctx)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, cnt)
+
+	cnt, err = cli.Table(user).Delete().
+		LeftJoin(team, user.TeamID.EqCol(team.ID)).
+		Where(team.ID.IsNull(true)).
+		Do(ctx)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, cnt)
+
+	_, err = cli.Table(user).Delete().
+		Join(team, user.TeamID.EqCol(team.ID)).
+		Limit(1).
+		Do(ctx)
+	assert.Error(t, err)
+	assert.NoError(t, m.MockDB.ExpectationsWereMet())
+}
+
+func Test_JoinWrite_Postgres(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{Dialect: orm.Postgres}).MustBuild()
+	cli := getClient(m)
+	m.MockDB.ExpectExec(`UPDATE "user" SET "name" = "team"."name" FROM "team" WHERE ("user"."team_id" = "team"."id" AND "team"."name" = $1)`).
+		WithArgs("team1").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	m.MockDB.ExpectQuery(`DELETE FROM "user" USING "team" WHERE ("user"."team_id" = "team"."id" AND "team"."name" = $1) RETURNING "user"."id", "user"."name"`).
+		WithArgs("team2").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(2, "archever2"))
+
+	cnt, err := cli.Table(user).
+		Update(user.Name.SetCol(team.Name)).
+		Join(team, user.TeamID.EqCol(team.ID)).
+		Where(team.Name.Eq("team1")).
+		Do(ctx)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, cnt)
+
+	var payload userPayload
+	err = cli.Table(user).Delete().
+		Join(team, user.TeamID.EqCol(team.ID)).
+		Where(team.Name.Eq("team2")).
+		Returning().
+		TakePayload(ctx, &payload)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, payload.ID)
+
+	_, err = cli.Table(user).Delete().
+		LeftJoin(team, user.TeamID.EqCol(team.ID)).
+		Do(ctx)
+	assert.ErrorIs(t, err, orm.ErrNotSupported)
+	assert.NoError(t, m.MockDB.ExpectationsWereMet())
+}
+
+func Test_JoinWrite_SQLite(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{Dialect: orm.SQLite}).MustBuild()
+	cli := getClient(m)
+	m.MockDB.ExpectExec(`DELETE FROM "user" WHERE EXISTS (SELECT 1 FROM "team" WHERE ("user"."team_id" = "team"."id" AND "team"."name" = ?))`).
+		WithArgs("team2").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	cnt, err := cli.Table(user).Delete().
+		Join(team, user.TeamID.EqCol(team.ID)).
+		Where(team.Name.Eq("team2")).
+		Do(ctx)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, cnt)
+	assert.NoError(t, m.MockDB.ExpectationsWereMet())
+}
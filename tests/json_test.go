@@ -0,0 +1,136 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/archever/orm"
+	"github.com/stretchr/testify/assert"
+)
+
+var settingTheme = setting.Data.JSONPath("$.theme")
+
+type settingThemePayload struct {
+	orm.PayloadBase
+	ID    int64
+	Theme string
+}
+
+func (p *settingThemePayload) Bind() {
+	p.PayloadBase.BindField(&p.ID, setting.ID)
+	orm.BindFieldIfc(&p.Theme, settingTheme, &p.PayloadBase)
+}
+
+func Test_JSON_Path(t *testing.T) {
+	ctx := context.Background()
+	{
+		m := (&mockInc{}).MustBuild()
+		cli := getClient(m)
+		m.MockDB.ExpectQuery("SELECT `id`, `data`->>'$.theme' FROM `setting` WHERE (`setting`.`data`->>'$.theme' = ? AND `setting`.`data`->>'$.size[0]' > ?) order by `setting`.`data`->>'$.theme' DESC").
+			WithArgs("dark", 10).
+			WillReturnRows(
+				sqlmock.NewRows([]string{"id", "theme"}).AddRow(1, "dark"),
+			)
+		var payload []*settingThemePayload
+		err := cli.Table(setting).Select().
+			Where(settingTheme.Eq("dark"), setting.Data.JSONPath("$.size[0]").Gt(10)).
+			OrderBy(settingTheme.Desc(true)).
+			FindPayload(ctx, &payload)
+		assert.NoError(t, err)
+		assert.Len(t, payload, 1)
+		assert.Equal(t, "dark", payload[0].Theme)
+	}
+	{
+		m := (&mockInc{Dialect: orm.Postgres}).MustBuild()
+		cli := getClient(m)
+		m.MockDB.ExpectQuery(`SELECT "id", "data"->>'theme' FROM "setting" WHERE "setting"."data"->'a b'->'list'->>0 = $1`).
+			WithArgs("x").
+			WillReturnRows(
+				sqlmock.NewRows([]string{"id", "theme"}).AddRow(1, "dark"),
+			)
+		var payload []*settingThemePayload
+		err := cli.Table(setting).Select().
+			Where(setting.Data.JSONPath(`$."a b".list[0]`).Eq("x")).
+			FindPayload(ctx, &payload)
+		assert.NoError(t, err)
+	}
+	{
+		// a non numeric index is quoted as a key, never pasted into the query
+		m := (&mockInc{Dialect: orm.Postgres}).MustBuild()
+		cli := getClient(m)
+		m.MockDB.ExpectQuery(`SELECT "id", "data"->>'theme' FROM "setting" WHERE "setting"."data"->'a'->>'0) OR (1=1' = $1`).
+			WithArgs("x").
+			WillReturnRows(
+				sqlmock.NewRows([]string{"id", "theme"}).AddRow(1, "dark"),
+			)
+		var payload []*settingThemePayload
+		err := cli.Table(setting).Select().
+			Where(setting.Data.JSONPath(`$.a[0) OR (1=1]`).Eq("x")).
+			FindPayload(ctx, &payload)
+		assert.NoError(t, err)
+	}
+}
+
+func Test_JSON_Contains(t *testing.T) {
+	ctx := context.Background()
+	{
+		m := (&mockInc{}).MustBuild()
+		cli := getClient(m)
+		m.MockDB.ExpectQuery("SELECT `id`, `data` FROM `setting` WHERE (JSON_CONTAINS(`setting`.`data`, ?) AND JSON_CONTAINS(`setting`.`data`, ?))").
+			WithArgs(`{"theme":"dark"}`, `["a"]`).
+			WillReturnRows(
+				sqlmock.NewRows([]string{"id", "data"}).AddRow(1, `{}`),
+			)
+		var payload []*settingPayload
+		err := cli.Table(setting).Select().
+			Where(
+				setting.Data.JSONContains(map[string]any{"theme": "dark"}),
+				setting.Data.JSONArrayContains("a"),
+			).
+			FindPayload(ctx, &payload)
+		assert.NoError(t, err)
+	}
+	{
+		m := (&mockInc{Dialect: orm.Postgres}).MustBuild()
+		cli := getClient(m)
+		m.MockDB.ExpectQuery(`SELECT "id", "data" FROM "setting" WHERE "setting"."data" @> $1::jsonb`).
+			WithArgs(`[1]`).
+			WillReturnRows(
+				sqlmock.NewRows([]string{"id", "data"}).AddRow(1, `[1]`),
+			)
+		var payload []*settingPayload
+		err := cli.Table(setting).Select().
+			Where(setting.Data.JSONArrayContains(1)).
+			FindPayload(ctx, &payload)
+		assert.NoError(t, err)
+	}
+}
+
+func Test_JSON_Set(t *testing.T) {
+	ctx := context.Background()
+	{
+		m := (&mockInc{}).MustBuild()
+		cli := getClient(m)
+		m.MockDB.ExpectExec("UPDATE `setting` SET `setting`.`data` = JSON_SET(`setting`.`data`, '$.theme', CAST(? AS JSON)) WHERE `setting`.`id` = ?").
+			WithArgs(`"light"`, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		_, err := cli.Table(setting).
+			Update(setting.Data.JSONSet("$.theme", "light")).
+			Where(setting.ID.Eq(1)).
+			Do(ctx)
+		assert.NoError(t, err)
+	}
+	{
+		m := (&mockInc{Dialect: orm.Postgres}).MustBuild()
+		cli := getClient(m)
+		m.MockDB.ExpectExec(`UPDATE "setting" SET "data" = jsonb_set("setting"."data", '{"size","0"}', $1::jsonb) WHERE "setting"."id" = $2`).
+			WithArgs(`12`, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		_, err := cli.Table(setting).
+			Update(setting.Data.JSONSet("$.size[0]", 12)).
+			Where(setting.ID.Eq(1)).
+			Do(ctx)
+		assert.NoError(t, err)
+	}
+}
@@ -0,0 +1,94 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/archever/orm"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Lock_ForUpdate(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{}).MustBuild()
+	cli := getClient(m)
+	m.MockDB.ExpectBegin()
+	m.MockDB.ExpectQuery("SELECT `id`, `name` FROM `user` WHERE `user`.`team_id` = ? LIMIT ? FOR UPDATE SKIP LOCKED").
+		WithArgs(1, 1).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "name"}).AddRow(10, "archever"),
+		)
+	m.MockDB.ExpectQuery("SELECT `user`.`id`, `user`.`name` FROM `user` JOIN `team` ON `user`.`team_id` = `team`.`id` FOR SHARE NOWAIT").
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "name"}).AddRow(10, "archever"),
+		)
+	m.MockDB.ExpectCommit()
+	err := cli.Transaction(ctx, func(s *orm.Session) error {
+		var payload userPayload
+		err := s.Table(user).Select().
+			Where(user.TeamID.Eq(1)).
+			ForUpdate().SkipLocked().
+			TakePayload(ctx, &payload)
+		if err != nil {
+			return err
+		}
+		var payloads []*userPayload
+		return s.Table(user).Select().
+			Join(team, user.TeamID.EqCol(team.ID)).
+			ForShare().NoWait().
+			FindPayload(ctx, &payloads)
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, m.MockDB.ExpectationsWereMet())
+}
+
+func Test_Lock_ForUpdateOf(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{Dialect: orm.Postgres}).MustBuild()
+	cli := getClient(m)
+	m.MockDB.ExpectBegin()
+	m.MockDB.ExpectQuery(`SELECT "user"."id", "user"."name" FROM "user" JOIN "team" ON "user"."team_id" = "team"."id" WHERE "team"."name" = $1 FOR UPDATE OF "user"`).
+		WithArgs("team1").
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "name"}).AddRow(10, "archever"),
+		)
+	m.MockDB.ExpectCommit()
+	err := cli.Transaction(ctx, func(s *orm.Session) error {
+		var payloads []*userPayload
+		return s.Table(user).Select().
+			Join(team, user.TeamID.EqCol(team.ID)).
+			Where(team.Name.Eq("team1")).
+			ForUpdateOf(user).
+			FindPayload(ctx, &payloads)
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, m.MockDB.ExpectationsWereMet())
+}
+
+func Test_Lock_Error(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{}).MustBuild()
+	cli := getClient(m)
+	var payloads []*userPayload
+	err := cli.Table(user).Select().ForUpdate().FindPayload(ctx, &payloads)
+	assert.Error(t, err)
+
+	m.MockDB.ExpectBegin()
+	m.MockDB.ExpectRollback()
+	err = cli.Transaction(ctx, func(s *orm.Session) error {
+		return s.Table(user).Select().NoWait().FindPayload(ctx, &payloads)
+	})
+	assert.Error(t, err)
+
+	m2 := (&mockInc{Dialect: orm.SQLite}).MustBuild()
+	cli = getClient(m2)
+	m2.MockDB.ExpectBegin()
+	m2.MockDB.ExpectRollback()
+	err = cli.Transaction(ctx, func(s *orm.Session) error {
+		return s.Table(user).Select().ForUpdate().FindPayload(ctx, &payloads)
+	})
+	assert.ErrorIs(t, err, orm.ErrNotSupported)
+	assert.NoError(t, m.MockDB.ExpectationsWereMet())
+	assert.NoError(t, m2.MockDB.ExpectationsWereMet())
+}
@@ -0,0 +1,81 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/archever/orm"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Pattern_Like(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{}).MustBuild()
+	cli := getClient(m)
+	m.MockDB.ExpectQuery("SELECT `id`, `name` FROM `user` WHERE (`user`.`name` LIKE ? AND `user`.`name` NOT LIKE ? AND `user`.`name` LIKE ? ESCAPE '!' AND `user`.`name` LIKE ? ESCAPE '!' AND `user`.`name` LIKE ? ESCAPE '!')").
+		WithArgs("a%", "%b", "%50!%!_off%", "ar!!%", "%ver").
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "name"}).AddRow(10, "archever"),
+		)
+	var payload []*userPayload
+	err := cli.Table(user).Select().
+		Where(
+			user.Name.Like("a%"),
+			user.Name.NotLike("%b"),
+			user.Name.Contains("50%_off"),
+			user.Name.StartsWith("ar!"),
+			user.Name.EndsWith("ver"),
+		).
+		FindPayload(ctx, &payload)
+	assert.NoError(t, err)
+	assert.Len(t, payload, 1)
+}
+
+func Test_Pattern_Between(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{}).MustBuild()
+	cli := getClient(m)
+	m.MockDB.ExpectQuery("SELECT `id`, `name` FROM `user` WHERE (`user`.`id` BETWEEN ? AND ? AND `user`.`team_id` NOT BETWEEN ? AND ?)").
+		WithArgs(1, 10, 3, 5).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "name"}).AddRow(10, "archever"),
+		)
+	var payload []*userPayload
+	err := cli.Table(user).Select().
+		Where(user.ID.Between(1, 10), user.TeamID.NotBetween(3, 5)).
+		FindPayload(ctx, &payload)
+	assert.NoError(t, err)
+}
+
+func Test_Pattern_Dialect(t *testing.T) {
+	ctx := context.Background()
+	{
+		m := (&mockInc{}).MustBuild()
+		cli := getClient(m)
+		m.MockDB.ExpectQuery("SELECT `id`, `name` FROM `user` WHERE (LOWER(`user`.`name`) LIKE LOWER(?) AND `user`.`name` REGEXP ?)").
+			WithArgs("arch%", "^a.*r$").
+			WillReturnRows(
+				sqlmock.NewRows([]string{"id", "name"}).AddRow(10, "archever"),
+			)
+		var payload []*userPayload
+		err := cli.Table(user).Select().
+			Where(user.Name.ILike("arch%"), user.Name.Regexp("^a.*r$")).
+			FindPayload(ctx, &payload)
+		assert.NoError(t, err)
+	}
+	{
+		m := (&mockInc{Dialect: orm.Postgres}).MustBuild()
+		cli := getClient(m)
+		m.MockDB.ExpectQuery(`SELECT "id", "name" FROM "user" WHERE ("user"."name" ILIKE $1 AND "user"."name" ~ $2 AND "user"."name" LIKE $3 ESCAPE '!')`).
+			WithArgs("arch%", "^a.*r$", "%!_%").
+			WillReturnRows(
+				sqlmock.NewRows([]string{"id", "name"}).AddRow(10, "archever"),
+			)
+		var payload []*userPayload
+		err := cli.Table(user).Select().
+			Where(user.Name.ILike("arch%"), user.Name.Regexp("^a.*r$"), user.Name.Contains("_")).
+			FindPayload(ctx, &payload)
+		assert.NoError(t, err)
+	}
+}
@@ -0,0 +1,136 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/archever/orm"
+	"github.com/stretchr/testify/assert"
+)
+
+type userScorePayload struct {
+	orm.PayloadBase
+	ID    int64
+	Score int64
+}
+
+func (p *userScorePayload) Bind() {
+	p.PayloadBase.BindField(&p.ID, user.ID)
+	orm.BindFieldIfc(&p.Score, orm.As(orm.Raw("`user`.`id` * ?", 2), "score"), &p.PayloadBase)
+}
+
+func Test_Raw_Select(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{}).MustBuild()
+	cli := getClient(m)
+	m.MockDB.ExpectQuery("SELECT `id`, `user`.`id` * ? AS `score` FROM `user` WHERE (`user`.`team_id` = ? AND FIND_IN_SET(?, `user`.`name`)) order by FIELD(`user`.`id`, ?, ?) DESC").
+		WithArgs(2, 1, "a", 3, 4).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "score"}).AddRow(3, 6),
+		)
+	var payload []*userScorePayload
+	err := cli.Table(user).Select().
+		Where(user.TeamID.Eq(1), orm.Raw("FIND_IN_SET(?, `user`.`name`)", "a").Cond()).
+		OrderBy(orm.Raw("FIELD(`user`.`id`, ?, ?)", 3, 4).Desc(true)).
+		FindPayload(ctx, &payload)
+	assert.NoError(t, err)
+	assert.Len(t, payload, 1)
+	assert.EqualValues(t, 6, payload[0].Score)
+}
+
+func Test_Raw_Postgres(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{Dialect: orm.Postgres}).MustBuild()
+	cli := getClient(m)
+	m.MockDB.ExpectQuery(`SELECT "user"."id", "user"."name" FROM "user" JOIN LATERAL (SELECT "team_id" FROM "team" WHERE "id" = $1) t ON "user"."team_id" = "t"."team_id" WHERE "user"."name" ~* $2 AND "user"."id" > $3`).
+		WithArgs(5, "^a", 1).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "name"}).AddRow(10, "archever"),
+		)
+	var payload []*userPayload
+	err := cli.Table(user).Select().
+		Join(
+			orm.Raw(`LATERAL (SELECT "team_id" FROM "team" WHERE "id" = ?) t`, 5),
+			user.TeamID.EqCol(*orm.NewField[int64]("team_id", orm.NewTable("t"))),
+		).
+		Where(orm.Raw(`"user"."name" ~* ? AND "user"."id" > ?`, "^a", 1).Cond()).
+		FindPayload(ctx, &payload)
+	assert.NoError(t, err)
+}
+
+func Test_Raw_Query(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{Dialect: orm.Postgres}).MustBuild()
+	cli := getClient(m)
+	{
+		m.MockDB.ExpectQuery(`SELECT name, extra, id FROM report WHERE id > $1 AND name <> '?'`).
+			WithArgs(1).
+			WillReturnRows(
+				sqlmock.NewRows([]string{"name", "extra", "id"}).
+					AddRow("archever", 1, 2).
+					AddRow("name", 2, 3),
+			)
+		var payload []*userPayload
+		err := cli.Raw(ctx, `SELECT name, extra, id FROM report WHERE id > ? AND name <> '?'`, 1).
+			FindPayload(&payload)
+		assert.NoError(t, err)
+		assert.Len(t, payload, 2)
+		assert.EqualValues(t, 2, payload[0].ID)
+		assert.Equal(t, "archever", payload[0].Name)
+		assert.EqualValues(t, 3, payload[1].ID)
+	}
+	{
+		m.MockDB.ExpectQuery(`SELECT u.id, u.name, t.id, t.name FROM "user" u JOIN team t ON u.team_id = t.id`).
+			WillReturnRows(
+				sqlmock.NewRows([]string{"id", "name", "id", "name"}).
+					AddRow(1, "archever", 2, "team2").
+					AddRow(3, "name", 4, "team4"),
+			)
+		var payload userPayload
+		var teamPayload *teamPayload
+		err := cli.Raw(ctx, `SELECT u.id, u.name, t.id, t.name FROM "user" u JOIN team t ON u.team_id = t.id`).
+			TakePayload(&payload, &teamPayload)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 1, payload.ID)
+		assert.Equal(t, "archever", payload.Name)
+		assert.EqualValues(t, 2, teamPayload.ID)
+		assert.Equal(t, "team2", teamPayload.Name)
+	}
+	{
+		m.MockDB.ExpectQuery(`SELECT count(*) FROM "user" WHERE team_id = $1`).
+			WithArgs(2).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+		var cnt int64
+		err := cli.Raw(ctx, `SELECT count(*) FROM "user" WHERE team_id = ?`, 2).Scan(&cnt)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 5, cnt)
+	}
+	{
+		m.MockDB.ExpectQuery(`SELECT id FROM "user" WHERE id = $1`).
+			WithArgs(100).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		var id int64
+		err := cli.Raw(ctx, `SELECT id FROM "user" WHERE id = ?`, 100).Scan(&id)
+		assert.ErrorIs(t, err, orm.ErrNotFund)
+	}
+	{
+		m.MockDB.ExpectQuery(`SELECT id, name FROM "user" WHERE id = $1`).
+			WithArgs(100).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+		var payload userPayload
+		err := cli.Raw(ctx, `SELECT id, name FROM "user" WHERE id = ?`, 100).TakePayload(&payload)
+		assert.ErrorIs(t, err, orm.ErrNotFund)
+	}
+	{
+		m.MockDB.ExpectExec(`UPDATE "user" SET name = $1 WHERE id = $2`).
+			WithArgs("archever", 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		ret, err := cli.Raw(ctx, `UPDATE "user" SET name = ? WHERE id = ?`, "archever", 1).Exec()
+		assert.NoError(t, err)
+		cnt, err := ret.RowsAffected()
+		assert.NoError(t, err)
+		assert.EqualValues(t, 1, cnt)
+	}
+	assert.NoError(t, m.MockDB.ExpectationsWereMet())
+}
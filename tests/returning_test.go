@@ -0,0 +1,69 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/archever/orm"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Returning_Insert(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{Dialect: orm.Postgres}).MustBuild()
+	cli := getClient(m)
+	m.MockDB.ExpectQuery(`INSERT INTO "user" ("name") VALUES($1),($2) RETURNING "id", "name"`).
+		WithArgs("name1", "name2").
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "name"}).AddRow(7, "name1").AddRow(3, "name2"),
+		)
+	payload1 := userPayload{Name: "name1"}
+	payload2 := userPayload{Name: "name2"}
+	cnt, err := cli.Table(user).InsertPayload(&payload1, &payload2).Returning().Do(ctx)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, cnt)
+	assert.EqualValues(t, 7, payload1.ID)
+	assert.EqualValues(t, 3, payload2.ID)
+}
+
+func Test_Returning_Update(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{Dialect: orm.Postgres}).MustBuild()
+	cli := getClient(m)
+	m.MockDB.ExpectQuery(`UPDATE "user" SET "id" = $1, "name" = $2 WHERE "user"."id" = $3 RETURNING "name"`).
+		WithArgs(10, "name1", 10).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"name"}).AddRow("trigger name"),
+		)
+	payload := userPayload{ID: 10, Name: "name1"}
+	cnt, err := cli.Table(user).UpdatePayload(&payload).Where(user.ID.Eq(10)).Returning(user.Name).Do(ctx)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, cnt)
+	assert.EqualValues(t, "trigger name", payload.Name)
+}
+
+func Test_Returning_Delete(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{Dialect: orm.Postgres}).MustBuild()
+	cli := getClient(m)
+	m.MockDB.ExpectQuery(`DELETE FROM "user" WHERE "user"."id" IN ($1,$2) RETURNING "id", "name"`).
+		WithArgs(10, 11).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "name"}).AddRow(10, "name1").AddRow(11, "name2"),
+		)
+	var payload []*userPayload
+	err := cli.Table(user).Delete().Where(user.ID.In(10, 11)).Returning().FindPayload(ctx, &payload)
+	assert.NoError(t, err)
+	assert.Len(t, payload, 2)
+	assert.EqualValues(t, 11, payload[1].ID)
+}
+
+func Test_Returning_MySQL(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{}).MustBuild()
+	cli := getClient(m)
+	payload := userPayload{Name: "name1"}
+	_, err := cli.Table(user).InsertPayload(&payload).Returning().Do(ctx)
+	assert.ErrorIs(t, err, orm.ErrNotSupported)
+}
@@ -42,6 +42,24 @@ func (s *teamSchema) IDField() orm.FieldIfc {
 	return s.ID
 }
 
+var setting = &settingSchema{
+	ID:   orm.Field[int64]{Name: "id", Schema: &settingSchema{}, AutoIncrement: true},
+	Data: orm.Field[string]{Name: "data", Schema: &settingSchema{}},
+}
+
+type settingSchema struct {
+	ID   orm.Field[int64]
+	Data orm.Field[string]
+}
+
+func (s *settingSchema) TableName() string {
+	return "setting"
+}
+
+func (s *settingSchema) IDField() orm.FieldIfc {
+	return s.ID
+}
+
 type userPayload struct {
 	orm.PayloadBase
 	ID   int64
@@ -52,3 +70,25 @@ func (p *userPayload) Bind() {
 	p.PayloadBase.BindField(&p.ID, user.ID)
 	p.PayloadBase.BindField(&p.Name, user.Name)
 }
+
+type teamPayload struct {
+	orm.PayloadBase
+	ID   int64
+	Name string
+}
+
+func (p *teamPayload) Bind() {
+	p.PayloadBase.BindField(&p.ID, team.ID)
+	p.PayloadBase.BindField(&p.Name, team.Name)
+}
+
+type settingPayload struct {
+	orm.PayloadBase
+	ID   int64
+	Data string
+}
+
+func (p *settingPayload) Bind() {
+	p.PayloadBase.BindField(&p.ID, setting.ID)
+	p.PayloadBase.BindField(&p.Data, setting.Data)
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/archever/orm"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -41,3 +42,34 @@ func Test_UpdatePayload_Simple(t *testing.T) {
 	assert.NoError(t, err)
 	assert.EqualValues(t, 1, cnt)
 }
+
+func Test_Update_Assignments(t *testing.T) {
+	ctx := context.Background()
+	{
+		m := (&mockInc{}).MustBuild()
+		cli := getClient(m)
+		m.MockDB.ExpectExec("UPDATE `user` SET `user`.`team_id` = `user`.`team_id` + ?, `user`.`id` = `user`.`id` - ?, `user`.`name` = NULL, `user`.`team_id` = `user`.`id` WHERE `user`.`id` = ?").
+			WithArgs(1, 2, 10).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		cnt, err := cli.Table(user).
+			Update(user.TeamID.Incr(1), user.ID.Decr(2)).
+			Set(user.Name.SetNull(), user.TeamID.SetCol(user.ID)).
+			Where(user.ID.Eq(10)).
+			Do(ctx)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 1, cnt)
+	}
+	{
+		m := (&mockInc{Dialect: orm.Postgres}).MustBuild()
+		cli := getClient(m)
+		m.MockDB.ExpectExec(`UPDATE "user" SET "team_id" = "user"."team_id" - $1, "name" = "user"."name" WHERE "user"."id" = $2`).
+			WithArgs(1, 10).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		cnt, err := cli.Table(user).
+			Update(user.TeamID.Decr(1), user.Name.SetExpr(user.Name)).
+			Where(user.ID.Eq(10)).
+			Do(ctx)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 1, cnt)
+	}
+}
@@ -0,0 +1,96 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/archever/orm"
+	"github.com/stretchr/testify/assert"
+)
+
+var userRank = orm.As(orm.DenseRank().Over(orm.PartitionBy(user.TeamID).OrderBy(user.Name.Asc())), "rk")
+
+type userRankPayload struct {
+	orm.PayloadBase
+	ID     int64
+	Rank   int64
+	PrevID int64
+	Total  int64
+}
+
+func (p *userRankPayload) Bind() {
+	p.PayloadBase.BindField(&p.ID, user.ID)
+	orm.BindFieldIfc(&p.Rank, userRank, &p.PayloadBase)
+	orm.BindFieldIfc(&p.PrevID, orm.Lag(user.ID, 1).Over(orm.PartitionBy().OrderBy(user.ID.Asc())), &p.PayloadBase)
+	orm.BindFieldIfc(&p.Total, orm.Count(nil).Over(orm.PartitionBy(user.TeamID)), &p.PayloadBase)
+}
+
+func Test_Window_Select(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{}).MustBuild()
+	cli := getClient(m)
+	m.MockDB.ExpectQuery("SELECT `id`, DENSE_RANK() OVER (PARTITION BY `team_id` ORDER BY `name`) AS `rk`, LAG(`id`, 1) OVER (ORDER BY `id`), COUNT(*) OVER (PARTITION BY `team_id`) FROM `user` order by `rk` DESC").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "rk", "prev", "total"}).AddRow(10, 1, 9, 3))
+	var payload []*userRankPayload
+	err := cli.Table(user).Select().
+		OrderBy(userRank.Desc(true)).
+		FindPayload(ctx, &payload)
+	assert.NoError(t, err)
+	assert.Len(t, payload, 1)
+	assert.EqualValues(t, 1, payload[0].Rank)
+	assert.EqualValues(t, 9, payload[0].PrevID)
+	assert.EqualValues(t, 3, payload[0].Total)
+}
+
+func Test_Window_Wrapped(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{}).MustBuild()
+	cli := getClient(m)
+	ranked := orm.NewTable("ranked")
+	rankedID := orm.NewField[int64]("id", ranked)
+	rankedRn := orm.NewField[int64]("rn", ranked)
+	m.MockDB.ExpectQuery("WITH `ranked` AS (SELECT `id`, ROW_NUMBER() OVER (PARTITION BY `team_id` ORDER BY `id` DESC) AS `rn` FROM `user`) SELECT `id`, `name` FROM `user` WHERE `user`.`id` IN (SELECT `id` FROM `ranked` WHERE `ranked`.`rn` = ?)").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(10, "archever"))
+	latest := cli.Table(user).Select(
+		user.ID,
+		orm.As(orm.RowNumber().Over(orm.PartitionBy(user.TeamID).OrderBy(user.ID.Desc(true))), "rn"),
+	)
+	var payload []*userPayload
+	err := cli.Table(user).Select().
+		With("ranked", latest).
+		Where(user.ID.InQuery(cli.Table(ranked).Select(rankedID).Where(rankedRn.Eq(1)).SubQuery())).
+		FindPayload(ctx, &payload)
+	assert.NoError(t, err)
+	assert.Len(t, payload, 1)
+}
+
+var vipRank = orm.As(orm.RowNumber().Over(orm.PartitionBy(user.TeamID).OrderBy(
+	orm.Case().When(user.Name.Eq("vip"), 0).Else(1).Asc(),
+)), "rn")
+
+type vipRankPayload struct {
+	orm.PayloadBase
+	ID   int64
+	Rank int64
+}
+
+func (p *vipRankPayload) Bind() {
+	p.PayloadBase.BindField(&p.ID, user.ID)
+	orm.BindFieldIfc(&p.Rank, vipRank, &p.PayloadBase)
+}
+
+func Test_Window_OrderByArgs(t *testing.T) {
+	ctx := context.Background()
+	m := (&mockInc{}).MustBuild()
+	cli := getClient(m)
+	m.MockDB.ExpectQuery("SELECT `id`, ROW_NUMBER() OVER (PARTITION BY `team_id` ORDER BY CASE WHEN `user`.`name` = ? THEN ? ELSE ? END) AS `rn` FROM `user` WHERE `user`.`id` > ?").
+		WithArgs("vip", 0, 1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "rn"}).AddRow(10, 1))
+	var payload []*vipRankPayload
+	err := cli.Table(user).Select().Where(user.ID.Gt(1)).FindPayload(ctx, &payload)
+	assert.NoError(t, err)
+	assert.Len(t, payload, 1)
+	assert.EqualValues(t, 1, payload[0].Rank)
+}
@@ -0,0 +1,151 @@
+package orm
+
+import (
+	"fmt"
+	"strings"
+)
+
+var _ FieldIfc = (*WindowFunc[any])(nil)
+
+// Window is the OVER clause of a window function
+type Window struct {
+	partitionBy []FieldIfc
+	orderBy     []Order
+}
+
+// PartitionBy starts a window partitioned by the fields, no partition if none
+func PartitionBy(field ...FieldIfc) *Window {
+	return &Window{partitionBy: field}
+}
+
+// OrderBy orders the rows in the partition
+func (w *Window) OrderBy(order ...Order) *Window {
+	w.orderBy = append(w.orderBy, order...)
+	return w
+}
+
+func (w *Window) render(d Dialect, withTableName bool) (string, []any) {
+	if w == nil {
+		return "", nil
+	}
+	parts := []string{}
+	args := []any{}
+	if len(w.partitionBy) > 0 {
+		fields := []string{}
+		for _, field := range w.partitionBy {
+			e, a := fieldExpr(d, field, withTableName)
+			fields = append(fields, e)
+			args = append(args, a...)
+		}
+		parts = append(parts, "PARTITION BY "+strings.Join(fields, ", "))
+	}
+	if len(w.orderBy) > 0 {
+		orders := []string{}
+		for _, o := range w.orderBy {
+			e, a := o.render(d, withTableName)
+			orders = append(orders, e)
+			args = append(args, a...)
+		}
+		parts = append(parts, "ORDER BY "+strings.Join(orders, ", "))
+	}
+	return strings.Join(parts, " "), args
+}
+
+// WindowFunc is a function computed over a window of rows, it works as a
+// field in select and order by, filter it from a wrapping query
+type WindowFunc[T any] struct {
+	typedOps[T]
+	fn     string
+	field  FieldIfc
+	offset int64
+	over   *Window
+}
+
+func newWindowFunc[T any](fn string, field FieldIfc, offset int64) *WindowFunc[T] {
+	w := &WindowFunc[T]{
+		fn:     fn,
+		field:  field,
+		offset: offset,
+	}
+	w.typedOps = typedOps[T]{self: w}
+	return w
+}
+
+func RowNumber() *WindowFunc[int64] {
+	return newWindowFunc[int64]("ROW_NUMBER", nil, 0)
+}
+
+func Rank() *WindowFunc[int64] {
+	return newWindowFunc[int64]("RANK", nil, 0)
+}
+
+func DenseRank() *WindowFunc[int64] {
+	return newWindowFunc[int64]("DENSE_RANK", nil, 0)
+}
+
+// Lag is the value of field offset rows before the current row
+func Lag[T any](field Field[T], offset int64) *WindowFunc[T] {
+	return newWindowFunc[T]("LAG", &field, offset)
+}
+
+// Lead is the value of field offset rows after the current row
+func Lead[T any](field Field[T], offset int64) *WindowFunc[T] {
+	return newWindowFunc[T]("LEAD", &field, offset)
+}
+
+// Over computes the aggregate over the window instead of the group
+func (a *Aggregate[T]) Over(w *Window) *WindowFunc[T] {
+	return newWindowFunc[T]("", a, 0).Over(w)
+}
+
+// Over sets the window of the function
+func (w *WindowFunc[T]) Over(window *Window) *WindowFunc[T] {
+	w.over = window
+	return w
+}
+
+func (w *WindowFunc[T]) computed() {}
+
+func (w *WindowFunc[T]) render(d Dialect, withTableName bool) (string, []any) {
+	call := ""
+	args := []any{}
+	switch {
+	case w.fn == "":
+		// aggregate
+		call, args = fieldExpr(d, w.field, withTableName)
+	case w.field == nil:
+		call = w.fn + "()"
+	default:
+		arg, a := fieldExpr(d, w.field, withTableName)
+		call = fmt.Sprintf("%s(%s, %d)", w.fn, arg, w.offset)
+		args = append(args, a...)
+	}
+	over, overArgs := w.over.render(d, withTableName)
+	return fmt.Sprintf("%s OVER (%s)", call, over), append(args, overArgs...)
+}
+
+func (w *WindowFunc[T]) selectExpr(d Dialect, withTableName bool) (string, []any) {
+	return w.render(d, withTableName)
+}
+
+func (w *WindowFunc[T]) IsAutoIncrement() bool {
+	return false
+}
+
+func (w *WindowFunc[T]) key() string {
+	return exprKey(w)
+}
+
+func (w *WindowFunc[T]) ColName(d Dialect) string {
+	expr, _ := w.render(d, false)
+	return expr
+}
+
+func (w *WindowFunc[T]) DBColName(d Dialect) string {
+	expr, _ := w.render(d, true)
+	return expr
+}
+
+func (w *WindowFunc[T]) Expr(d Dialect) (string, []any) {
+	return w.render(d, true)
+}